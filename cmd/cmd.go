@@ -6,16 +6,35 @@ import (
 	"log/slog"
 	"os"
 	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/LeBulldoge/gungus/internal/config"
 	"github.com/LeBulldoge/gungus/internal/database"
 	"github.com/LeBulldoge/gungus/internal/discord"
+	"github.com/LeBulldoge/gungus/internal/discord/bot"
+	"github.com/LeBulldoge/gungus/internal/discord/commands/play/playback"
+	"github.com/LeBulldoge/gungus/internal/discord/ops"
+	"github.com/LeBulldoge/gungus/internal/health"
+	"github.com/LeBulldoge/gungus/internal/metrics"
 	gos "github.com/LeBulldoge/gungus/internal/os"
+	"github.com/LeBulldoge/gungus/internal/server"
+	"github.com/LeBulldoge/gungus/internal/settings"
+	"github.com/LeBulldoge/gungus/internal/youtube"
+	"github.com/bwmarrin/discordgo"
 )
 
 // flags
 var (
-	configDir = flag.String("config", "", "Config directory")
-	botToken  = flag.String("token", "", "Bot token")
+	configDir    = flag.String("config", "", "Config directory")
+	botToken     = flag.String("token", "", "Bot token")
+	defaultGuild = flag.String("default-guild", "", "Guild ID to assign data created before guild scoping to")
+	logLevel     = flag.String("log-level", "", "Log level: debug, info, warn or error")
+	commandList  = flag.String("commands", "", "Comma separated list of enabled commands")
+	devGuilds    = flag.String("dev-guild", "", "Comma separated list of guild IDs to register commands to instead of globally")
+	ytdlpPath    = flag.String("ytdlp", "", "Path to the yt-dlp executable")
+	ffmpegPath   = flag.String("ffmpeg", "", "Path to the ffmpeg executable")
+	httpAddr     = flag.String("http", "", "Address to serve metrics and health checks on, e.g. :9090")
 )
 
 func Run(version string, build string) {
@@ -23,31 +42,165 @@ func Run(version string, build string) {
 
 	slog.Info("starting gungus", "version", version, "build", build)
 
-	if len(*botToken) == 0 {
-		slog.Error("Please provide a discord bot authentication token.\nMore info at https://discord.com/developers/docs/getting-started")
+	if len(*configDir) > 0 {
+		gos.SetCustomConfigDir(*configDir)
+	}
+
+	cfg, err := config.Load(gos.ConfigPath())
+	if err != nil {
+		slog.Error("error while loading configuration", "err", err)
 		return
 	}
 
-	if len(*configDir) > 0 {
-		gos.SetCustomConfigDir(*configDir)
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "token":
+			cfg.Token = *botToken
+		case "default-guild":
+			cfg.DefaultGuild = *defaultGuild
+		case "log-level":
+			cfg.LogLevel = *logLevel
+		case "commands":
+			cfg.Commands = config.SplitList(*commandList)
+		case "dev-guild":
+			cfg.DevGuilds = config.SplitList(*devGuilds)
+		case "ytdlp":
+			cfg.YtDlpPath = *ytdlpPath
+		case "ffmpeg":
+			cfg.FFmpegPath = *ffmpegPath
+		case "http":
+			cfg.HTTPAddr = *httpAddr
+		}
+	})
+
+	if err := cfg.Validate(); err != nil {
+		slog.Error("invalid configuration", "err", err)
+		return
+	}
+
+	slog.SetLogLoggerLevel(cfg.SlogLevel())
+	if len(cfg.DevGuilds) > 0 {
+		slog.Info("development mode, registering commands to guilds only", "guilds", cfg.DevGuilds)
+	}
+	youtube.SetExecutablePath(cfg.YtDlpPath)
+	settings.IdleTimeout.SetDefault(cfg.Play.IdleTimeout)
+	if err := playback.SetFFmpegPath(cfg.FFmpegPath); err != nil {
+		slog.Error("error while setting ffmpeg path", "err", err)
+		return
 	}
 
 	storage := database.New(gos.ConfigPath())
-	err := storage.Open(context.TODO())
+	err = storage.Open(context.TODO())
 	if err != nil {
 		slog.Error("error while opening database", "err", err)
+		reportStartupFailure(cfg, "Database migration failed", err)
 		return
 	}
 
-	bot, err := discord.StartBot(*botToken, storage)
+	if len(cfg.DefaultGuild) > 0 {
+		err = storage.AssignDefaultGuild(context.TODO(), cfg.DefaultGuild)
+		if err != nil {
+			slog.Error("error while assigning default guild", "err", err)
+			reportStartupFailure(cfg, "Assigning the default guild failed", err)
+			return
+		}
+	}
+
+	info := bot.Info{
+		Version:   version,
+		Build:     build,
+		StartedAt: time.Now(),
+		Tools:     map[string]string{},
+	}
+	if cfg.CommandEnabled("play") {
+		info.Tools = probeTools(context.TODO())
+	}
+
+	gungus, err := discord.StartBot(cfg, storage, info)
 	if err != nil {
 		slog.Error("error while starting bot", "err", err)
 		return
 	}
-	defer bot.Shutdown()
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+		defer cancel()
+		discord.StopBot(ctx, gungus)
+	}()
+
+	if len(cfg.HTTPAddr) > 0 {
+		// liveness only covers what a restart can fix
+		live := health.Checks{
+			"gateway":  gungus.CheckGateway,
+			"database": storage.Ping,
+		}
+		ready := health.Checks{
+			"gateway":  gungus.CheckGateway,
+			"database": storage.Ping,
+		}
+		if cfg.CommandEnabled("play") {
+			ready["yt-dlp"] = health.Executable(cfg.YtDlpPath)
+			ready["ffmpeg"] = health.Executable(cfg.FFmpegPath)
+		}
+
+		srv := server.New(cfg.HTTPAddr)
+		srv.Handle("/metrics", metrics.Handler())
+		srv.Handle("/healthz", health.Handler(live))
+		srv.Handle("/readyz", health.Handler(ready))
+		srv.Start()
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_ = srv.Shutdown(ctx)
+		}()
+	}
 
 	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
 	slog.Info("Press Ctrl+C to exit")
-	<-stop
+	sig := <-stop
+	slog.Info("received signal", "signal", sig.String())
+}
+
+// reportStartupFailure posts err to the global ops channel, since failures before
+// the bot starts are otherwise only visible in the logs.
+func reportStartupFailure(cfg config.Config, title string, err error) {
+	if len(cfg.OpsChannel) == 0 {
+		return
+	}
+
+	session, serr := discordgo.New("Bot " + cfg.Token)
+	if serr == nil {
+		id := ops.NewCorrelationID()
+		slog.Info("reporting failure to the ops channel", "id", id)
+		serr = ops.Send(session, cfg.OpsChannel, ops.Report{Title: title, ID: id, Err: err})
+	}
+	if serr != nil {
+		slog.Error("failure posting to the ops channel", "err", serr)
+	}
+}
+
+// probeTools detects the versions of the executables used for playback,
+// so outdated or broken installs show up in the logs and in /status.
+func probeTools(ctx context.Context) map[string]string {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	probes := map[string]func(context.Context) (string, error){
+		"yt-dlp": youtube.Version,
+		"ffmpeg": playback.FFmpegVersion,
+	}
+
+	tools := make(map[string]string, len(probes))
+	for name, probe := range probes {
+		version, err := probe(ctx)
+		if err != nil {
+			slog.Warn("failure detecting tool version", "tool", name, "err", err)
+			version = "unknown"
+		} else {
+			slog.Info("detected tool version", "tool", name, "version", version)
+		}
+		tools[name] = version
+	}
+
+	return tools
 }
@@ -0,0 +1,136 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/LeBulldoge/gungus/internal/database"
+	"github.com/LeBulldoge/sqlighter"
+	"github.com/bwmarrin/discordgo"
+)
+
+// Entry records who took a mutating action, where and when.
+// Command is the command path of the action, like "movie remove",
+// and Payload the JSON encoded details of it.
+type Entry struct {
+	ID        int64     `db:"id"`
+	GuildID   string    `db:"guildId"`
+	ChannelID string    `db:"channelId"`
+	UserID    string    `db:"userId"`
+	Command   string    `db:"command"`
+	Payload   string    `db:"payload"`
+	Date      time.Time `db:"date"`
+}
+
+// Payload holds the details of an action.
+type Payload map[string]any
+
+// Record saves an entry for the action taken through intr.
+func Record(ctx context.Context, storage *database.Storage, intr *discordgo.InteractionCreate, command string, payload Payload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failure encoding audit payload: %w", err)
+	}
+
+	return storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO AuditLog (guildId, channelId, userId, command, payload, date) VALUES (?, ?, ?, ?, ?, ?)",
+			intr.GuildID, intr.ChannelID, userID(intr), command, string(data), time.Now().UTC(),
+		)
+		if err != nil {
+			return fmt.Errorf("failure saving audit entry: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func userID(intr *discordgo.InteractionCreate) string {
+	if intr.Member != nil && intr.Member.User != nil {
+		return intr.Member.User.ID
+	}
+	if intr.User != nil {
+		return intr.User.ID
+	}
+	return ""
+}
+
+// Filter narrows down the entries returned by Query. Empty fields match everything.
+// Command matches the command path and its subcommands.
+type Filter struct {
+	GuildID string
+	UserID  string
+	Command string
+	Since   time.Time
+	Until   time.Time
+	Limit   int
+}
+
+// Query returns the entries of a guild matching filter, newest first.
+func Query(ctx context.Context, storage *database.Storage, filter Filter) ([]Entry, error) {
+	res := []Entry{}
+
+	query, args := filter.sql()
+
+	return res, storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
+		err := tx.SelectContext(ctx, &res, query, args...)
+		if err != nil {
+			return fmt.Errorf("failure getting audit entries: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Commands returns the distinct command paths recorded in a guild.
+func Commands(ctx context.Context, storage *database.Storage, guildID string) ([]string, error) {
+	res := []string{}
+
+	return res, storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
+		err := tx.SelectContext(ctx, &res, "SELECT DISTINCT command FROM AuditLog WHERE guildId = ? ORDER BY command", guildID)
+		if err != nil {
+			return fmt.Errorf("failure getting audited commands: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (f Filter) sql() (string, []any) {
+	conds := []string{"guildId = ?"}
+	args := []any{f.GuildID}
+
+	if len(f.UserID) > 0 {
+		conds = append(conds, "userId = ?")
+		args = append(args, f.UserID)
+	}
+	if len(f.Command) > 0 {
+		conds = append(conds, "(command = ? OR command LIKE ? ESCAPE '\\')")
+		args = append(args, f.Command, escapeLike(f.Command)+" %")
+	}
+	if !f.Since.IsZero() {
+		conds = append(conds, "date >= ?")
+		args = append(args, f.Since.UTC())
+	}
+	if !f.Until.IsZero() {
+		conds = append(conds, "date < ?")
+		args = append(args, f.Until.UTC())
+	}
+
+	query := "SELECT * FROM AuditLog WHERE " + strings.Join(conds, " AND ") + " ORDER BY date DESC, id DESC"
+	if f.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, f.Limit)
+	}
+
+	return query, args
+}
+
+var likeEscaper = strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_")
+
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
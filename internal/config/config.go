@@ -0,0 +1,265 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+const (
+	FileName  = "config.toml"
+	envPrefix = "GUNGUS_"
+)
+
+type Config struct {
+	Token        string   `toml:"token"`
+	LogLevel     string   `toml:"log_level"`
+	DefaultGuild string   `toml:"default_guild"`
+	Commands     []string `toml:"commands"`
+	DevGuilds    []string `toml:"dev_guilds"`
+	YtDlpPath    string   `toml:"ytdlp_path"`
+	FFmpegPath   string   `toml:"ffmpeg_path"`
+	// HTTPAddr is the address of the metrics and health endpoints, disabled when empty.
+	HTTPAddr string `toml:"http_addr"`
+	// ShutdownTimeout bounds the time given to players and in-flight interactions to finish.
+	ShutdownTimeout time.Duration `toml:"shutdown_timeout"`
+	// OpsChannel is the ID of a channel where failures in every guild are reported, disabled when empty.
+	OpsChannel string `toml:"ops_channel"`
+
+	Play      Play      `toml:"play"`
+	RateLimit RateLimit `toml:"ratelimit"`
+}
+
+// Play holds settings of the audio playback commands.
+// IdleTimeout is the default of the play.idle_timeout guild setting.
+type Play struct {
+	Bitrate         int           `toml:"bitrate"`
+	IdleTimeout     time.Duration `toml:"idle_timeout"`
+	PlaylistTimeout time.Duration `toml:"playlist_timeout"`
+}
+
+// RateLimit holds the limits on how often commands can be used.
+type RateLimit struct {
+	// ExemptRoles are IDs of roles not subject to the limits.
+	ExemptRoles []string        `toml:"exempt_roles"`
+	Rules       []RateLimitRule `toml:"rules"`
+}
+
+// Rate limit scopes, the subject sharing a bucket of tokens.
+const (
+	ScopeUser    = "user"
+	ScopeChannel = "channel"
+	ScopeGuild   = "guild"
+)
+
+// RateLimitRule allows Burst uses of a command path and its subcommands at once
+// per user, channel or guild, refilling one every Interval.
+type RateLimitRule struct {
+	Command  string        `toml:"command"`
+	Scope    string        `toml:"scope"`
+	Burst    int           `toml:"burst"`
+	Interval time.Duration `toml:"interval"`
+}
+
+func Default() Config {
+	return Config{
+		LogLevel:   "info",
+		YtDlpPath:  "yt-dlp",
+		FFmpegPath: "ffmpeg",
+		// docker stop kills the container after 10 seconds
+		ShutdownTimeout: 8 * time.Second,
+		Play: Play{
+			Bitrate:         64,
+			IdleTimeout:     time.Minute,
+			PlaylistTimeout: time.Minute,
+		},
+		RateLimit: RateLimit{
+			Rules: []RateLimitRule{
+				{Command: "play", Scope: ScopeUser, Burst: 5, Interval: 10 * time.Second},
+				{Command: "quote random", Scope: ScopeChannel, Burst: 5, Interval: 30 * time.Second},
+				{Command: "quote vote", Scope: ScopeUser, Burst: 10, Interval: 10 * time.Second},
+				{Command: "poll vote", Scope: ScopeUser, Burst: 10, Interval: 10 * time.Second},
+			},
+		},
+	}
+}
+
+// Load builds the configuration from the defaults, the config file in configDir
+// and GUNGUS_* environment variables, in that order of precedence.
+func Load(configDir string) (Config, error) {
+	cfg := Default()
+
+	path := filepath.Join(configDir, FileName)
+	_, err := toml.DecodeFile(path, &cfg)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return cfg, fmt.Errorf("failure reading config file %s: %w", path, err)
+	}
+
+	err = applyEnv(reflect.ValueOf(&cfg).Elem(), envPrefix)
+	if err != nil {
+		return cfg, fmt.Errorf("failure reading environment: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// applyEnv overrides the fields of v with environment variables named after
+// their toml keys, e.g. play.idle_timeout is read from GUNGUS_PLAY_IDLE_TIMEOUT.
+func applyEnv(v reflect.Value, prefix string) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key, _, _ := strings.Cut(field.Tag.Get("toml"), ",")
+		if len(key) == 0 {
+			continue
+		}
+		name := prefix + strings.ToUpper(key)
+
+		if field.Type.Kind() == reflect.Struct {
+			if err := applyEnv(v.Field(i), name+"_"); err != nil {
+				return err
+			}
+			continue
+		}
+
+		val, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		if err := setValue(v.Field(i), val); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func setValue(v reflect.Value, val string) error {
+	if v.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(val)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(val)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(val)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported slice type %s", v.Type())
+		}
+		v.Set(reflect.ValueOf(SplitList(val)))
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	return nil
+}
+
+// SplitList splits a comma separated list, trimming its entries and dropping empty ones.
+func SplitList(val string) []string {
+	parts := []string{}
+	for _, p := range strings.Split(val, ",") {
+		if p = strings.TrimSpace(p); len(p) > 0 {
+			parts = append(parts, p)
+		}
+	}
+	return parts
+}
+
+// CommandEnabled reports whether the command is enabled. All commands are enabled
+// when the list is empty.
+func (c *Config) CommandEnabled(name string) bool {
+	return len(c.Commands) == 0 || slices.Contains(c.Commands, name)
+}
+
+func (c *Config) SlogLevel() slog.Level {
+	var level slog.Level
+	_ = level.UnmarshalText([]byte(c.LogLevel))
+	return level
+}
+
+// Validate reports every problem with the configuration at once.
+func (c *Config) Validate() error {
+	var errs []error
+
+	if len(c.Token) == 0 {
+		errs = append(errs, errors.New("a discord bot authentication token is required, more info at https://discord.com/developers/docs/getting-started"))
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		errs = append(errs, fmt.Errorf("invalid log_level %q: %w", c.LogLevel, err))
+	}
+
+	if c.CommandEnabled("play") {
+		if _, err := exec.LookPath(c.YtDlpPath); err != nil {
+			errs = append(errs, fmt.Errorf("invalid ytdlp_path: %w", err))
+		}
+		if _, err := exec.LookPath(c.FFmpegPath); err != nil {
+			errs = append(errs, fmt.Errorf("invalid ffmpeg_path: %w", err))
+		}
+	}
+
+	if c.ShutdownTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("shutdown_timeout must be positive, got %s", c.ShutdownTimeout))
+	}
+
+	if c.Play.Bitrate < 1 || c.Play.Bitrate > 512 {
+		errs = append(errs, fmt.Errorf("play.bitrate must be between 1 and 512, got %d", c.Play.Bitrate))
+	}
+	if c.Play.IdleTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("play.idle_timeout must be positive, got %s", c.Play.IdleTimeout))
+	}
+	if c.Play.PlaylistTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("play.playlist_timeout must be positive, got %s", c.Play.PlaylistTimeout))
+	}
+
+	for i, rule := range c.RateLimit.Rules {
+		if len(rule.Command) == 0 {
+			errs = append(errs, fmt.Errorf("ratelimit.rules[%d].command is required", i))
+		}
+		if !slices.Contains([]string{ScopeUser, ScopeChannel, ScopeGuild}, rule.Scope) {
+			errs = append(errs, fmt.Errorf("ratelimit.rules[%d].scope must be user, channel or guild, got %q", i, rule.Scope))
+		}
+		if rule.Burst < 1 {
+			errs = append(errs, fmt.Errorf("ratelimit.rules[%d].burst must be positive, got %d", i, rule.Burst))
+		}
+		if rule.Interval <= 0 {
+			errs = append(errs, fmt.Errorf("ratelimit.rules[%d].interval must be positive, got %s", i, rule.Interval))
+		}
+	}
+
+	return errors.Join(errs...)
+}
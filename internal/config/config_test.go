@@ -0,0 +1,111 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	file := `
+token = "file-token"
+log_level = "debug"
+commands = ["play", "quote"]
+
+[play]
+bitrate = 96
+idle_timeout = "5m"
+
+[ratelimit]
+exempt_roles = ["123"]
+
+[[ratelimit.rules]]
+command = "movie add"
+scope = "guild"
+burst = 10
+interval = "1m"
+`
+	err := os.WriteFile(filepath.Join(dir, FileName), []byte(file), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("GUNGUS_TOKEN", "env-token")
+	t.Setenv("GUNGUS_PLAY_IDLE_TIMEOUT", "30s")
+
+	want := Default()
+	want.Token = "env-token"
+	want.LogLevel = "debug"
+	want.Commands = []string{"play", "quote"}
+	want.Play.Bitrate = 96
+	want.Play.IdleTimeout = 30 * time.Second
+	want.RateLimit = RateLimit{
+		ExemptRoles: []string{"123"},
+		Rules:       []RateLimitRule{{Command: "movie add", Scope: ScopeGuild, Burst: 10, Interval: time.Minute}},
+	}
+
+	cfg, err := Load(dir)
+	if err != nil {
+		t.Fatalf("error received. got %+v", err)
+	}
+
+	if !reflect.DeepEqual(cfg, want) {
+		t.Fatalf("wrong config received. got %+v, expected, %+v", cfg, want)
+	}
+}
+
+func TestLoadWithoutFile(t *testing.T) {
+	t.Setenv("GUNGUS_COMMANDS", "movie, poll")
+
+	cfg, err := Load(t.TempDir())
+	if err != nil {
+		t.Fatalf("error received. got %+v", err)
+	}
+
+	want := []string{"movie", "poll"}
+	if !reflect.DeepEqual(cfg.Commands, want) {
+		t.Fatalf("wrong commands received. got %+v, expected, %+v", cfg.Commands, want)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cfg := Default()
+	cfg.Commands = []string{"quote"}
+	cfg.LogLevel = "loud"
+	cfg.Play.Bitrate = 0
+	cfg.RateLimit.Rules = append(cfg.RateLimit.Rules, RateLimitRule{Command: "play", Scope: "planet", Burst: 1, Interval: time.Second})
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+
+	cfg = Default()
+	cfg.Token = "token"
+	cfg.Commands = []string{"quote"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("error received. got %+v", err)
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	tests := []struct {
+		val  string
+		want []string
+	}{
+		{"", []string{}},
+		{"play,quote", []string{"play", "quote"}},
+		{"play, quote", []string{"play", "quote"}},
+		{" play,,quote, ", []string{"play", "quote"}},
+	}
+
+	for _, tt := range tests {
+		if got := SplitList(tt.val); !slices.Equal(got, tt.want) {
+			t.Errorf("wrong list received for %q. got %q, expected, %q", tt.val, got, tt.want)
+		}
+	}
+}
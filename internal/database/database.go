@@ -3,17 +3,25 @@ package database
 import (
 	"context"
 	"sync"
+	"time"
 
+	"github.com/LeBulldoge/gungus/internal/metrics"
 	"github.com/LeBulldoge/sqlighter"
+	"github.com/LeBulldoge/sqlighter/schema"
 )
 
 type Storage struct {
 	db *sqlighter.DB
 	mu sync.Mutex
+
+	settings settingsCache
 }
 
 func New(configDir string) *Storage {
-	return &Storage{db: sqlighter.New(configDir, targetVersion, versionMap)}
+	return &Storage{
+		db:       sqlighter.New(configDir, targetVersion, versionMap),
+		settings: settingsCache{guilds: make(map[string]map[string]string), generations: make(map[string]uint64)},
+	}
 }
 
 func (m *Storage) Open(ctx context.Context) error {
@@ -25,6 +33,11 @@ func (m *Storage) Close() error {
 }
 
 func (m *Storage) Tx(ctx context.Context, f func(context.Context, *sqlighter.Tx) error) error {
+	start := time.Now()
+	defer func() {
+		metrics.TxDuration.Observe(time.Since(start).Seconds())
+	}()
+
 	return m.db.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
 		m.mu.Lock()
 		defer m.mu.Unlock()
@@ -32,6 +45,26 @@ func (m *Storage) Tx(ctx context.Context, f func(context.Context, *sqlighter.Tx)
 	})
 }
 
+// Ping checks that the database can be queried.
+func (m *Storage) Ping(ctx context.Context) error {
+	return m.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
+		_, err := tx.ExecContext(ctx, "SELECT 1")
+		return err
+	})
+}
+
+// SchemaVersion returns the version the database schema is migrated to.
+func (m *Storage) SchemaVersion(ctx context.Context) (int, error) {
+	var version int
+	err := m.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
+		var err error
+		version, err = schema.CurrentVersion(ctx, tx)
+		return err
+	})
+
+	return version, err
+}
+
 type WithStorage struct {
 	storage *Storage
 }
@@ -0,0 +1,28 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/LeBulldoge/sqlighter"
+)
+
+// AssignDefaultGuild attributes rows created before guild scoping to guildID.
+// Movie ratings and cast follow their movies through ON UPDATE CASCADE.
+func (m *Storage) AssignDefaultGuild(ctx context.Context, guildID string) error {
+	return m.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
+		queries := []string{
+			"UPDATE Quotes SET guildId = ? WHERE guildId = ''",
+			"UPDATE OR IGNORE Movies SET guildId = ? WHERE guildId = ''",
+			"UPDATE Polls SET guild_id = ? WHERE guild_id = ''",
+		}
+		for _, q := range queries {
+			_, err := tx.ExecContext(ctx, q, guildID)
+			if err != nil {
+				return fmt.Errorf("failure assigning default guild: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
@@ -8,10 +8,10 @@ import (
 	"github.com/LeBulldoge/sqlighter"
 )
 
-func (m *Storage) GetPoll(ID string) (poll.Poll, error) {
+func (m *Storage) GetPoll(guildID string, ID string) (poll.Poll, error) {
 	p := poll.Poll{}
-	err := m.db.Tx(context.TODO(), func(ctx context.Context, tx *sqlighter.Tx) error {
-		err := tx.GetContext(ctx, &p, "SELECT * FROM Polls WHERE id = ?", ID)
+	err := m.Tx(context.TODO(), func(ctx context.Context, tx *sqlighter.Tx) error {
+		err := tx.GetContext(ctx, &p, "SELECT * FROM Polls WHERE id = ? AND guild_id = ?", ID, guildID)
 		if err != nil {
 			return fmt.Errorf("error while getting poll: %w", err)
 		}
@@ -46,8 +46,8 @@ func (m *Storage) GetPoll(ID string) (poll.Poll, error) {
 }
 
 func (m *Storage) AddPoll(p poll.Poll) error {
-	return m.db.Tx(context.TODO(), func(ctx context.Context, tx *sqlighter.Tx) error {
-		_, err := tx.ExecContext(ctx, "INSERT INTO Polls (id, owner, title) VALUES (?, ?, ?)", p.ID, p.Owner, p.Title)
+	return m.Tx(context.TODO(), func(ctx context.Context, tx *sqlighter.Tx) error {
+		_, err := tx.ExecContext(ctx, "INSERT INTO Polls (id, owner, title, guild_id) VALUES (?, ?, ?, ?)", p.ID, p.Owner, p.Title, p.GuildID)
 		if err != nil {
 			return err
 		}
@@ -63,10 +63,13 @@ func (m *Storage) AddPoll(p poll.Poll) error {
 	})
 }
 
-func (m *Storage) CastVote(pollID string, option string, voterID string) error {
-	return m.db.Tx(context.TODO(), func(ctx context.Context, tx *sqlighter.Tx) error {
+func (m *Storage) CastVote(guildID string, pollID string, option string, voterID string) error {
+	return m.Tx(context.TODO(), func(ctx context.Context, tx *sqlighter.Tx) error {
 		var optionID string
-		err := tx.GetContext(ctx, &optionID, "SELECT id FROM PollOptions WHERE poll_id = ? AND name = ?", pollID, option)
+		err := tx.GetContext(ctx, &optionID, `SELECT PollOptions.id FROM PollOptions
+      JOIN Polls ON Polls.id = PollOptions.poll_id
+      WHERE PollOptions.poll_id = ? AND PollOptions.name = ? AND Polls.guild_id = ?`,
+			pollID, option, guildID)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,100 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sync"
+
+	"github.com/LeBulldoge/sqlighter"
+)
+
+type settingsCache struct {
+	mu     sync.RWMutex
+	guilds map[string]map[string]string
+	// generations count the invalidations of each guild, so settings read
+	// from the database before one aren't cached after it
+	generations map[string]uint64
+}
+
+// GuildSettings returns the raw values of the settings saved for a guild by key.
+// They are read from the database once and cached afterwards.
+func (m *Storage) GuildSettings(ctx context.Context, guildID string) (map[string]string, error) {
+	m.settings.mu.RLock()
+	cached, ok := m.settings.guilds[guildID]
+	generation := m.settings.generations[guildID]
+	m.settings.mu.RUnlock()
+	if ok {
+		return maps.Clone(cached), nil
+	}
+
+	res := make(map[string]string)
+	err := m.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
+		rows, err := tx.QueryContext(ctx, "SELECT key, value FROM GuildSettings WHERE guildId = ?", guildID)
+		if err != nil {
+			return fmt.Errorf("failure getting guild settings: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var key, value string
+			if err := rows.Scan(&key, &value); err != nil {
+				return fmt.Errorf("failure reading guild setting: %w", err)
+			}
+			res[key] = value
+		}
+
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	m.settings.mu.Lock()
+	if m.settings.generations[guildID] == generation {
+		m.settings.guilds[guildID] = res
+	}
+	m.settings.mu.Unlock()
+
+	return maps.Clone(res), nil
+}
+
+func (m *Storage) SetGuildSetting(ctx context.Context, guildID string, key string, value string) error {
+	err := m.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO GuildSettings (guildId, key, value) VALUES (?, ?, ?) ON CONFLICT (guildId, key) DO UPDATE SET value = excluded.value",
+			guildID, key, value,
+		)
+		if err != nil {
+			return fmt.Errorf("failure saving guild setting %s: %w", key, err)
+		}
+
+		return nil
+	})
+
+	m.invalidateSettings(guildID)
+
+	return err
+}
+
+func (m *Storage) DeleteGuildSetting(ctx context.Context, guildID string, key string) error {
+	err := m.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
+		_, err := tx.ExecContext(ctx, "DELETE FROM GuildSettings WHERE guildId = ? AND key = ?", guildID, key)
+		if err != nil {
+			return fmt.Errorf("failure deleting guild setting %s: %w", key, err)
+		}
+
+		return nil
+	})
+
+	m.invalidateSettings(guildID)
+
+	return err
+}
+
+func (m *Storage) invalidateSettings(guildID string) {
+	m.settings.mu.Lock()
+	defer m.settings.mu.Unlock()
+	delete(m.settings.guilds, guildID)
+	m.settings.generations[guildID]++
+}
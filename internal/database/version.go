@@ -4,9 +4,42 @@ import (
 	"github.com/LeBulldoge/sqlighter/schema"
 )
 
-const targetVersion = 7
+const targetVersion = 18
 
 var versionMap = schema.VersionMap{
+	18: schema.Version{
+		Up: version18Up,
+	},
+	17: schema.Version{
+		Up: version17Up,
+	},
+	16: schema.Version{
+		Up: version16Up,
+	},
+	15: schema.Version{
+		Up: version15Up,
+	},
+	14: schema.Version{
+		Up: version14Up,
+	},
+	13: schema.Version{
+		Up: version13Up,
+	},
+	12: schema.Version{
+		Up: version12Up,
+	},
+	11: schema.Version{
+		Up: version11Up,
+	},
+	10: schema.Version{
+		Up: version10Up,
+	},
+	9: schema.Version{
+		Up: version9Up,
+	},
+	8: schema.Version{
+		Up: version8Up,
+	},
 	7: schema.Version{
 		Up: version7Up,
 	},
@@ -30,6 +63,208 @@ var versionMap = schema.VersionMap{
 	},
 }
 
+// Add the lines of conversation quotes, each said by a user or by a name for non-members
+const version18Up = `CREATE TABLE QuoteLines (
+  quoteId  INTEGER NOT NULL REFERENCES Quotes (id) ON DELETE CASCADE,
+  position INTEGER NOT NULL,
+  user     TEXT    NOT NULL DEFAULT '',
+  name     TEXT    NOT NULL,
+  text     TEXT    NOT NULL,
+  PRIMARY KEY (quoteId, position)
+);
+
+CREATE INDEX QuoteLinesUser ON QuoteLines (user);`
+
+// Add the votes of members on quotes, summed up in the score of the quotes
+const version17Up = `CREATE TABLE QuoteVotes (
+  quoteId INTEGER NOT NULL REFERENCES Quotes (id) ON DELETE CASCADE,
+  voter   TEXT    NOT NULL,
+  value   INTEGER NOT NULL CHECK (value IN (-1, 1)),
+  date    DATETIME NOT NULL,
+  PRIMARY KEY (quoteId, voter)
+);`
+
+// Add quote scores, the shuffle bags of random quotes per guild and per user, an empty user
+// for the whole guild, holding the quotes already drawn, and the quotes recently shown by guilds
+const version16Up = `ALTER TABLE Quotes ADD score INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE QuoteBags (
+  guildId TEXT    NOT NULL,
+  user    TEXT    NOT NULL,
+  quoteId INTEGER NOT NULL REFERENCES Quotes (id) ON DELETE CASCADE,
+  PRIMARY KEY (guildId, user, quoteId)
+);
+
+CREATE TABLE QuotesShown (
+  id      INTEGER PRIMARY KEY AUTOINCREMENT,
+  guildId TEXT    NOT NULL,
+  quoteId INTEGER NOT NULL REFERENCES Quotes (id) ON DELETE CASCADE
+);
+
+CREATE INDEX QuotesShownGuild ON QuotesShown (guildId, id);`
+
+// Add the quote of the day schedules of guilds, and the quotes already posted by them
+const version15Up = `CREATE TABLE QuoteOfTheDay (
+  guildId      TEXT NOT NULL PRIMARY KEY,
+  channelId    TEXT NOT NULL,
+  time         TEXT NOT NULL,
+  timezone     TEXT NOT NULL,
+  lastPostedOn TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE QuoteOfTheDayPosted (
+  guildId TEXT    NOT NULL,
+  quoteId INTEGER NOT NULL REFERENCES Quotes (id) ON DELETE CASCADE,
+  PRIMARY KEY (guildId, quoteId)
+);`
+
+// Add full text search of quotes, kept in sync with the quotes by triggers
+const version14Up = `
+CREATE VIRTUAL TABLE QuotesSearch USING fts5(
+  text,
+  content='Quotes',
+  content_rowid='id',
+  tokenize='unicode61 remove_diacritics 2'
+);
+
+INSERT INTO QuotesSearch (QuotesSearch) VALUES ('rebuild');
+
+CREATE TRIGGER QuotesSearchInsert AFTER INSERT ON Quotes BEGIN
+  INSERT INTO QuotesSearch (rowid, text) VALUES (new.id, new.text);
+END;
+
+CREATE TRIGGER QuotesSearchDelete AFTER DELETE ON Quotes BEGIN
+  INSERT INTO QuotesSearch (QuotesSearch, rowid, text) VALUES ('delete', old.id, old.text);
+END;
+
+CREATE TRIGGER QuotesSearchUpdate AFTER UPDATE OF text ON Quotes BEGIN
+  INSERT INTO QuotesSearch (QuotesSearch, rowid, text) VALUES ('delete', old.id, old.text);
+  INSERT INTO QuotesSearch (rowid, text) VALUES (new.id, new.text);
+END;`
+
+// Number quotes and keep who submitted them.
+// Existing quotes are numbered in the order they were said in.
+const version13Up = `
+CREATE TABLE QuotesNew (
+  id          INTEGER  NOT NULL PRIMARY KEY AUTOINCREMENT,
+  guildId     TEXT     NOT NULL DEFAULT '',
+  user        TEXT     NOT NULL,
+  text        TEXT     NOT NULL,
+  date        DATETIME NOT NULL,
+  channelId   TEXT     NOT NULL DEFAULT '',
+  messageId   TEXT     NOT NULL DEFAULT '',
+  attachments TEXT     NOT NULL DEFAULT '[]',
+  submittedBy TEXT     NOT NULL DEFAULT ''
+);
+
+INSERT INTO QuotesNew (guildId, user, text, date, channelId, messageId, attachments)
+SELECT guildId, user, text, date, channelId, messageId, attachments FROM Quotes ORDER BY date;
+
+DROP TABLE Quotes;
+
+ALTER TABLE QuotesNew RENAME TO Quotes;
+
+CREATE INDEX QuotesGuildUser ON Quotes (guildId, user);`
+
+// Keep where quotes saved from messages came from
+const version12Up = `
+ALTER TABLE Quotes ADD COLUMN channelId TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE Quotes ADD COLUMN messageId TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE Quotes ADD COLUMN attachments TEXT NOT NULL DEFAULT '[]';`
+
+// Add the audit log of mutating actions
+const version11Up = `CREATE TABLE AuditLog (
+  id        INTEGER  NOT NULL PRIMARY KEY AUTOINCREMENT,
+  guildId   TEXT     NOT NULL,
+  channelId TEXT     NOT NULL,
+  userId    TEXT     NOT NULL,
+  command   TEXT     NOT NULL,
+  payload   TEXT     NOT NULL,
+  date      DATETIME NOT NULL
+);
+
+CREATE INDEX AuditLogGuildDate ON AuditLog (guildId, date);`
+
+// Add per guild settings
+const version10Up = `CREATE TABLE GuildSettings (
+  guildId TEXT NOT NULL,
+  key     TEXT NOT NULL,
+  value   TEXT NOT NULL,
+  PRIMARY KEY (guildId, key)
+);`
+
+// Add per guild command permission rules
+const version9Up = `CREATE TABLE Permissions (
+  guildId    TEXT    NOT NULL,
+  command    TEXT    NOT NULL,
+  targetId   TEXT    NOT NULL,
+  targetType TEXT    NOT NULL CHECK (targetType IN ('role', 'user')),
+  allow      INTEGER NOT NULL,
+  PRIMARY KEY (guildId, command, targetId)
+);`
+
+// Scope quotes, movies and polls to a guild.
+// Existing rows get an empty guildId, which is backfilled by Storage.AssignDefaultGuild.
+const version8Up = `
+PRAGMA foreign_keys=OFF;
+
+ALTER TABLE Quotes ADD COLUMN guildId TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE Polls ADD COLUMN guild_id TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE MoviesNew (
+    id          TEXT     NOT NULL,
+    guildId     TEXT     NOT NULL DEFAULT '',
+    title       TEXT     NOT NULL,
+    description TEXT     NOT NULL,
+    image       TEXT     NOT NULL,
+    addedBy     TEXT     NOT NULL,
+    watchedOn   DATETIME NOT NULL,
+    PRIMARY KEY(id, guildId)
+);
+
+INSERT INTO MoviesNew (id, title, description, image, addedBy, watchedOn)
+SELECT id, title, description, image, addedBy, watchedOn FROM Movies;
+
+CREATE TABLE MovieRatingsNew (
+    movieId     TEXT     NOT NULL,
+    guildId     TEXT     NOT NULL DEFAULT '',
+    userId      TEXT     NOT NULL,
+    rating      NUMBER   NOT NULL,
+    PRIMARY KEY(movieId, guildId, userId),
+    FOREIGN KEY(movieId, guildId) REFERENCES Movies(id, guildId)
+        ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+INSERT INTO MovieRatingsNew (movieId, userId, rating)
+SELECT movieId, userId, rating FROM MovieRatings;
+
+CREATE TABLE MovieCastNew (
+    movieId   TEXT NOT NULL,
+    guildId   TEXT NOT NULL DEFAULT '',
+    userId    TEXT NOT NULL,
+    character TEXT NOT NULL,
+    PRIMARY KEY(movieId, guildId, userId),
+    FOREIGN KEY(movieId, guildId) REFERENCES Movies(id, guildId)
+        ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+INSERT INTO MovieCastNew (movieId, userId, character)
+SELECT movieId, userId, character FROM MovieCast;
+
+DROP TABLE MovieCast;
+DROP TABLE MovieRatings;
+DROP TABLE Movies;
+
+ALTER TABLE MoviesNew RENAME TO Movies;
+ALTER TABLE MovieRatingsNew RENAME TO MovieRatings;
+ALTER TABLE MovieCastNew RENAME TO MovieCast;
+
+PRAGMA foreign_keys=ON;
+`
+
 // References Poll (id) -> References Polls(id)
 // How did this even work before???
 const version7Up = `
@@ -1,57 +1,126 @@
 package bot
 
 import (
+	"context"
+	"errors"
 	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/LeBulldoge/gungus/internal/config"
 	"github.com/LeBulldoge/gungus/internal/database"
+	"github.com/LeBulldoge/gungus/internal/discord/ops"
+	"github.com/LeBulldoge/gungus/internal/discord/router"
+	"github.com/LeBulldoge/gungus/internal/metrics"
 	"github.com/bwmarrin/discordgo"
 )
 
 type Bot struct {
 	Session *discordgo.Session
 	Storage *database.Storage
-}
+	Config  config.Config
+	Router  *router.Router
+	Info    Info
+	Ops     *ops.Reporter
+	// Commands are the signatures of the enabled commands, set before they are set up.
+	Commands []*discordgo.ApplicationCommand
+	// Features are the signatures of the commands guilds can disable, by feature.
+	// They are registered to each guild instead of globally.
+	Features map[string][]*discordgo.ApplicationCommand
+
+	guildSync *sync.Mutex
 
-func NewBot(token string, storage *database.Storage) (*Bot, error) {
-	s, err := discordgo.New("Bot " + token)
-	return &Bot{Session: s, Storage: storage}, err
+	ctx    context.Context
+	cancel context.CancelCauseFunc
 }
 
-func (bot *Bot) OpenConnection() error {
-	return bot.Session.Open()
+// ErrShutdown is the cause of the bot context being cancelled.
+var ErrShutdown = errors.New("bot is shutting down")
+
+// Info describes the running instance of the bot.
+type Info struct {
+	Version   string
+	Build     string
+	StartedAt time.Time
+	// Tools maps the external executables to their detected versions.
+	Tools map[string]string
 }
 
-func (bot *Bot) CreateCommands(commands []*discordgo.ApplicationCommand) error {
-	for _, v := range commands {
-		_, err := bot.Session.ApplicationCommandCreate(bot.Session.State.User.ID, "", v)
-		if err != nil {
-			slog.Error("error while creating command", "cmd", v.Name, "err", err)
-			return err
+func NewBot(cfg config.Config, storage *database.Storage, info Info) (*Bot, error) {
+	s, err := discordgo.New("Bot " + cfg.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	r := router.New()
+	s.AddHandler(r.Dispatch)
+
+	// discordgo sends Connect on every (re)connection to the gateway
+	var connected atomic.Bool
+	s.AddHandler(func(_ *discordgo.Session, _ *discordgo.Connect) {
+		if !connected.CompareAndSwap(false, true) {
+			metrics.GatewayReconnects.Inc()
 		}
+	})
+
+	ctx, cancel := context.WithCancelCause(context.Background())
 
-		slog.Info("created command", "cmd", v.Name)
+	bot := &Bot{
+		Session:   s,
+		Storage:   storage,
+		Config:    cfg,
+		Router:    r,
+		Info:      info,
+		Ops:       ops.NewReporter(s, storage, cfg.OpsChannel),
+		ctx:       ctx,
+		cancel:    cancel,
+		guildSync: &sync.Mutex{},
+	}
+	r.Use(router.LogOutcome(), router.Instrument(), router.Recover(), bot.enforceFeatures(), bot.enforcePermissions(), bot.limitRate())
+
+	return bot, nil
+}
+
+// Context is cancelled with ErrShutdown when the bot starts shutting down.
+// Commands should stop their background work when it is done.
+func (bot *Bot) Context() context.Context {
+	return bot.ctx
+}
+
+// Stop stops dispatching interactions and cancels the bot context.
+func (bot *Bot) Stop() {
+	bot.Router.Close()
+	bot.cancel(ErrShutdown)
+}
+
+// CheckGateway reports whether the session is connected to the discord gateway.
+func (bot *Bot) CheckGateway(context.Context) error {
+	bot.Session.RLock()
+	defer bot.Session.RUnlock()
+	if !bot.Session.DataReady {
+		return errors.New("not connected to the gateway")
 	}
 
 	return nil
 }
 
+func (bot *Bot) OpenConnection() error {
+	return bot.Session.Open()
+}
+
+// Shutdown disconnects from discord and closes the storage. Stop should be called
+// first and all users of the storage given a chance to finish.
 func (bot *Bot) Shutdown() {
-	err := bot.Storage.Close()
-	if err != nil {
-		slog.Error("failure closing database connection", "err", err)
-	}
+	bot.cancel(ErrShutdown)
 
-	slog.Info("Removing commands...")
-	registeredCommands, err := bot.Session.ApplicationCommands(bot.Session.State.User.ID, "")
-	if err != nil {
-		slog.Error("could not fetch registered commands", "err", err)
+	if err := bot.Session.Close(); err != nil {
+		slog.Error("failure closing discord session", "err", err)
 	}
 
-	for _, v := range registeredCommands {
-		err := bot.Session.ApplicationCommandDelete(bot.Session.State.User.ID, "", v.ID)
-		if err != nil {
-			slog.Error("cannot delete command", "cmd", v.Name, "err", err)
-		}
+	err := bot.Storage.Close()
+	if err != nil {
+		slog.Error("failure closing database connection", "err", err)
 	}
 
 	slog.Info("gracefully shutting down.")
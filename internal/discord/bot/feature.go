@@ -0,0 +1,30 @@
+package bot
+
+import (
+	"context"
+
+	"github.com/LeBulldoge/gungus/internal/discord/format"
+	"github.com/LeBulldoge/gungus/internal/discord/i18n"
+	"github.com/LeBulldoge/gungus/internal/discord/router"
+	"github.com/LeBulldoge/gungus/internal/settings"
+	"github.com/bwmarrin/discordgo"
+)
+
+// enforceFeatures refuses interactions with features disabled in the guild. Their commands
+// are removed from the guild, but buttons on older messages and stale clients remain.
+func (bot *Bot) enforceFeatures() router.Middleware {
+	return func(next router.Handler) router.Handler {
+		return func(ctx context.Context, sesh *discordgo.Session, intr *discordgo.InteractionCreate) {
+			feature := router.Feature(ctx)
+			if len(feature) == 0 || len(intr.GuildID) == 0 || settings.FeatureEnabled(ctx, bot.Storage, intr.GuildID, feature) {
+				next(ctx, sesh, intr)
+				return
+			}
+
+			router.Logger(ctx).Info("feature is disabled", "feature", feature, "guildId", intr.GuildID)
+			if intr.Type != discordgo.InteractionApplicationCommandAutocomplete {
+				format.DisplayInteractionError(sesh, intr, i18n.T(intr, "errors.feature_disabled"))
+			}
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package bot
+
+import (
+	"context"
+
+	"github.com/LeBulldoge/gungus/internal/discord/format"
+	"github.com/LeBulldoge/gungus/internal/discord/i18n"
+	"github.com/LeBulldoge/gungus/internal/discord/router"
+	"github.com/LeBulldoge/gungus/internal/permission"
+	"github.com/bwmarrin/discordgo"
+)
+
+// enforcePermissions refuses commands the permission rules of the guild deny the member,
+// along with the components and modals of those commands. Admins, autocomplete requests
+// and interactions outside of guilds aren't checked.
+func (bot *Bot) enforcePermissions() router.Middleware {
+	return func(next router.Handler) router.Handler {
+		return func(ctx context.Context, sesh *discordgo.Session, intr *discordgo.InteractionCreate) {
+			path := router.Command(ctx)
+			if len(path) == 0 || intr.Member == nil || permission.IsAdmin(intr.Member) {
+				next(ctx, sesh, intr)
+				return
+			}
+
+			rules, err := permission.GetRules(ctx, bot.Storage, intr.GuildID)
+			if err != nil {
+				router.Logger(ctx).Error("failure getting permission rules", "err", err)
+				format.DisplayInteractionWithError(sesh, intr, i18n.T(intr, "errors.checking_permissions"), err)
+				return
+			}
+
+			decision, matched := permission.Evaluate(rules, intr.GuildID, path, intr.Member)
+			if decision == permission.Denied {
+				router.Logger(ctx).Info("command denied by permission rules", "route", path, "user", intr.Member.User.ID)
+				format.DisplayInteractionError(sesh, intr, i18n.T(intr, "errors.no_permission"))
+				return
+			}
+
+			next(permission.WithDecision(ctx, decision, matched == path), sesh, intr)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/LeBulldoge/gungus/internal/database"
+	"github.com/LeBulldoge/gungus/internal/discord/router"
+	"github.com/LeBulldoge/gungus/internal/permission"
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestEnforcePermissionsOnComponents(t *testing.T) {
+	ctx := context.Background()
+	storage := database.New(t.TempDir())
+	if err := storage.Open(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer storage.Close()
+
+	err := permission.SetRule(ctx, storage, permission.Rule{GuildID: "guild", Command: "quote", TargetID: "troll", TargetType: permission.TargetUser, Allow: false})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bot := &Bot{Storage: storage}
+	r := router.New()
+	r.Use(bot.enforcePermissions())
+
+	handled := map[string]bool{}
+	r.WithCommand("quote").HandleComponent("quotevote", func(_ context.Context, _ *discordgo.Session, intr *discordgo.InteractionCreate) {
+		handled[intr.Member.User.ID] = true
+	})
+
+	sesh, _ := discordgo.New("")
+	sesh.Client = &http.Client{Transport: failingTransport{}}
+	sesh.MaxRestRetries = 0
+
+	for _, user := range []string{"troll", "fan"} {
+		r.Dispatch(sesh, &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+			ID:      "1",
+			GuildID: "guild",
+			Type:    discordgo.InteractionMessageComponent,
+			Member:  &discordgo.Member{User: &discordgo.User{ID: user}},
+			Data:    discordgo.MessageComponentInteractionData{CustomID: router.NewCustomID("quotevote", "1", "1").String()},
+		}})
+	}
+
+	if handled["troll"] {
+		t.Fatal("denied member voted")
+	}
+	if !handled["fan"] {
+		t.Fatal("member without rules couldn't vote")
+	}
+}
+
+type failingTransport struct{}
+
+func (failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("no connection")
+}
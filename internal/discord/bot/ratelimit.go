@@ -0,0 +1,89 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/LeBulldoge/gungus/internal/config"
+	"github.com/LeBulldoge/gungus/internal/discord/format"
+	"github.com/LeBulldoge/gungus/internal/discord/i18n"
+	"github.com/LeBulldoge/gungus/internal/discord/router"
+	"github.com/LeBulldoge/gungus/internal/ratelimit"
+	"github.com/bwmarrin/discordgo"
+)
+
+// limitRate refuses commands used more often than the rate limit rules allow. Components and
+// modals count as uses of the command owning them. Members with an exempt role aren't limited.
+func (bot *Bot) limitRate() router.Middleware {
+	limiter := ratelimit.New()
+	cfg := bot.Config.RateLimit
+
+	return func(next router.Handler) router.Handler {
+		return func(ctx context.Context, sesh *discordgo.Session, intr *discordgo.InteractionCreate) {
+			path := router.Command(ctx)
+			if len(path) == 0 || isExempt(cfg, intr.Member) {
+				next(ctx, sesh, intr)
+				return
+			}
+
+			reqs := rateLimitRequests(cfg.Rules, path, intr)
+			if ok, wait := limiter.Allow(reqs...); !ok {
+				seconds := int(math.Ceil(wait.Seconds()))
+				router.Logger(ctx).Info("command rate limited", "route", path, "wait", wait)
+				format.DisplayInteractionError(sesh, intr, i18n.T(intr, "errors.rate_limited", seconds))
+				return
+			}
+
+			next(ctx, sesh, intr)
+		}
+	}
+}
+
+func isExempt(cfg config.RateLimit, member *discordgo.Member) bool {
+	if member == nil {
+		return false
+	}
+
+	for _, role := range member.Roles {
+		if slices.Contains(cfg.ExemptRoles, role) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// rateLimitRequests returns a request for the bucket of every rule applying to the command path.
+func rateLimitRequests(rules []config.RateLimitRule, path string, intr *discordgo.InteractionCreate) []ratelimit.Request {
+	var reqs []ratelimit.Request
+	for _, rule := range rules {
+		if path != rule.Command && !strings.HasPrefix(path, rule.Command+" ") {
+			continue
+		}
+
+		var subject string
+		switch rule.Scope {
+		case config.ScopeUser:
+			if intr.Member != nil {
+				subject = intr.Member.User.ID
+			} else if intr.User != nil {
+				subject = intr.User.ID
+			}
+		case config.ScopeChannel:
+			subject = intr.ChannelID
+		case config.ScopeGuild:
+			subject = intr.GuildID
+		}
+
+		reqs = append(reqs, ratelimit.Request{
+			// Buckets are per rule, so overlapping rules don't share tokens
+			Key:   fmt.Sprintf("%s/%s/%s", rule.Command, rule.Scope, subject),
+			Limit: ratelimit.Limit{Burst: rule.Burst, Interval: rule.Interval},
+		})
+	}
+
+	return reqs
+}
@@ -0,0 +1,43 @@
+package bot
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/LeBulldoge/gungus/internal/config"
+	"github.com/LeBulldoge/gungus/internal/discord/router"
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestLimitRateOfComponents(t *testing.T) {
+	bot := &Bot{Config: config.Config{RateLimit: config.RateLimit{Rules: []config.RateLimitRule{
+		{Command: "quote vote", Scope: config.ScopeUser, Burst: 2, Interval: time.Hour},
+	}}}}
+	r := router.New()
+	r.Use(bot.limitRate())
+
+	votes := 0
+	r.WithCommand("quote vote").HandleComponent("quotevote", func(context.Context, *discordgo.Session, *discordgo.InteractionCreate) {
+		votes++
+	})
+
+	sesh, _ := discordgo.New("")
+	sesh.Client = &http.Client{Transport: failingTransport{}}
+	sesh.MaxRestRetries = 0
+
+	for range 3 {
+		r.Dispatch(sesh, &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+			ID:      "1",
+			GuildID: "guild",
+			Type:    discordgo.InteractionMessageComponent,
+			Member:  &discordgo.Member{User: &discordgo.User{ID: "spammer"}},
+			Data:    discordgo.MessageComponentInteractionData{CustomID: router.NewCustomID("quotevote", "1", "1").String()},
+		}})
+	}
+
+	if votes != 2 {
+		t.Fatalf("wrong number of votes let through. got %d, expected, %d", votes, 2)
+	}
+}
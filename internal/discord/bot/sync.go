@@ -0,0 +1,233 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+
+	"github.com/LeBulldoge/gungus/internal/settings"
+	"github.com/bwmarrin/discordgo"
+)
+
+type commandDiff struct {
+	Created []string
+	Updated []string
+	Removed []string
+}
+
+func (d commandDiff) Empty() bool {
+	return len(d.Created) == 0 && len(d.Updated) == 0 && len(d.Removed) == 0
+}
+
+// SyncCommands makes the commands registered in the guild match desired.
+// Nothing is sent to discord when they already match. Otherwise the whole set
+// is overwritten in bulk, which keeps unchanged commands and their IDs in place.
+// An empty guildID stands for global commands.
+func (bot *Bot) SyncCommands(guildID string, desired []*discordgo.ApplicationCommand) error {
+	appID := bot.Session.State.User.ID
+
+	registered, err := bot.Session.ApplicationCommands(appID, guildID)
+	if err != nil {
+		return fmt.Errorf("failure fetching registered commands: %w", err)
+	}
+
+	diff := diffCommands(desired, registered)
+	log := slog.With("guildId", guildID)
+	if diff.Empty() {
+		log.Info("commands are up to date", "count", len(desired))
+		return nil
+	}
+
+	_, err = bot.Session.ApplicationCommandBulkOverwrite(appID, guildID, desired)
+	if err != nil {
+		return fmt.Errorf("failure overwriting commands: %w", err)
+	}
+
+	log.Info("commands synced", "created", diff.Created, "updated", diff.Updated, "removed", diff.Removed)
+
+	return nil
+}
+
+// coreCommands returns the commands which aren't part of a feature.
+func (bot *Bot) coreCommands() []*discordgo.ApplicationCommand {
+	res := []*discordgo.ApplicationCommand{}
+	for _, cmd := range bot.Commands {
+		inFeature := false
+		for _, sigs := range bot.Features {
+			inFeature = inFeature || slices.Contains(sigs, cmd)
+		}
+		if !inFeature {
+			res = append(res, cmd)
+		}
+	}
+
+	return res
+}
+
+// SyncGlobalCommands registers the commands which aren't part of a feature globally.
+// In development mode they are registered to the development guilds instead, by SyncGuildCommands.
+func (bot *Bot) SyncGlobalCommands() error {
+	if len(bot.Config.DevGuilds) > 0 {
+		return nil
+	}
+
+	return bot.SyncCommands("", bot.coreCommands())
+}
+
+// SyncGuildCommands registers the commands of the features enabled in the guild to it.
+// In development mode, only development guilds have commands, including the core ones.
+func (bot *Bot) SyncGuildCommands(ctx context.Context, guildID string) error {
+	desired := []*discordgo.ApplicationCommand{}
+	if len(bot.Config.DevGuilds) > 0 {
+		if !slices.Contains(bot.Config.DevGuilds, guildID) {
+			return nil
+		}
+		desired = append(desired, bot.coreCommands()...)
+	}
+
+	for _, feature := range slices.Sorted(maps.Keys(bot.Features)) {
+		if settings.FeatureEnabled(ctx, bot.Storage, guildID, feature) {
+			desired = append(desired, bot.Features[feature]...)
+		}
+	}
+
+	bot.guildSync.Lock()
+	defer bot.guildSync.Unlock()
+
+	return bot.SyncCommands(guildID, desired)
+}
+
+func diffCommands(desired []*discordgo.ApplicationCommand, registered []*discordgo.ApplicationCommand) commandDiff {
+	diff := commandDiff{}
+
+	type key struct {
+		name string
+		t    discordgo.ApplicationCommandType
+	}
+	registeredByKey := make(map[key]*discordgo.ApplicationCommand, len(registered))
+	for _, cmd := range registered {
+		registeredByKey[key{cmd.Name, commandType(cmd)}] = cmd
+	}
+
+	for _, cmd := range desired {
+		k := key{cmd.Name, commandType(cmd)}
+		reg, ok := registeredByKey[k]
+		switch {
+		case !ok:
+			diff.Created = append(diff.Created, cmd.Name)
+		case !commandEqual(cmd, reg):
+			diff.Updated = append(diff.Updated, cmd.Name)
+		}
+		delete(registeredByKey, k)
+	}
+
+	for _, cmd := range registeredByKey {
+		diff.Removed = append(diff.Removed, cmd.Name)
+	}
+	slices.Sort(diff.Removed)
+
+	return diff
+}
+
+func commandType(cmd *discordgo.ApplicationCommand) discordgo.ApplicationCommandType {
+	if cmd.Type == 0 {
+		return discordgo.ChatApplicationCommand
+	}
+	return cmd.Type
+}
+
+// commandEqual compares the user facing parts of a desired command to a registered one.
+// Optional fields are only compared when the desired command sets them, since discord
+// fills in its own defaults.
+func commandEqual(want *discordgo.ApplicationCommand, got *discordgo.ApplicationCommand) bool {
+	if want.Description != got.Description ||
+		!localizationsEqual(want.NameLocalizations, got.NameLocalizations) ||
+		!localizationsEqual(want.DescriptionLocalizations, got.DescriptionLocalizations) ||
+		!int64PtrEqual(want.DefaultMemberPermissions, got.DefaultMemberPermissions) ||
+		!boolPtrEqual(want.NSFW, got.NSFW) {
+		return false
+	}
+
+	if want.Contexts != nil && (got.Contexts == nil || !slices.Equal(*want.Contexts, *got.Contexts)) {
+		return false
+	}
+
+	if want.IntegrationTypes != nil && (got.IntegrationTypes == nil || !slices.Equal(*want.IntegrationTypes, *got.IntegrationTypes)) {
+		return false
+	}
+
+	return optionsEqual(want.Options, got.Options)
+}
+
+func optionsEqual(want []*discordgo.ApplicationCommandOption, got []*discordgo.ApplicationCommandOption) bool {
+	if len(want) != len(got) {
+		return false
+	}
+
+	for i := range want {
+		a, b := want[i], got[i]
+		if a.Type != b.Type ||
+			a.Name != b.Name ||
+			a.Description != b.Description ||
+			a.Required != b.Required ||
+			a.Autocomplete != b.Autocomplete ||
+			a.MaxValue != b.MaxValue ||
+			a.MaxLength != b.MaxLength ||
+			!float64PtrEqual(a.MinValue, b.MinValue) ||
+			!intPtrEqual(a.MinLength, b.MinLength) ||
+			!slices.Equal(a.ChannelTypes, b.ChannelTypes) ||
+			!maps.Equal(a.NameLocalizations, b.NameLocalizations) ||
+			!maps.Equal(a.DescriptionLocalizations, b.DescriptionLocalizations) ||
+			!choicesEqual(a.Choices, b.Choices) ||
+			!optionsEqual(a.Options, b.Options) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func choicesEqual(want []*discordgo.ApplicationCommandOptionChoice, got []*discordgo.ApplicationCommandOptionChoice) bool {
+	if len(want) != len(got) {
+		return false
+	}
+
+	for i := range want {
+		if want[i].Name != got[i].Name ||
+			fmt.Sprint(want[i].Value) != fmt.Sprint(got[i].Value) ||
+			!maps.Equal(want[i].NameLocalizations, got[i].NameLocalizations) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func localizationsEqual(want *map[discordgo.Locale]string, got *map[discordgo.Locale]string) bool {
+	var a, b map[discordgo.Locale]string
+	if want != nil {
+		a = *want
+	}
+	if got != nil {
+		b = *got
+	}
+	return maps.Equal(a, b)
+}
+
+func boolPtrEqual(want *bool, got *bool) bool {
+	return want == nil || (got != nil && *want == *got) || (got == nil && !*want)
+}
+
+func int64PtrEqual(want *int64, got *int64) bool {
+	return (want == nil && got == nil) || (want != nil && got != nil && *want == *got)
+}
+
+func intPtrEqual(want *int, got *int) bool {
+	return (want == nil && got == nil) || (want != nil && got != nil && *want == *got)
+}
+
+func float64PtrEqual(want *float64, got *float64) bool {
+	return (want == nil && got == nil) || (want != nil && got != nil && *want == *got)
+}
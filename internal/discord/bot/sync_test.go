@@ -0,0 +1,76 @@
+package bot
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestDiffCommands(t *testing.T) {
+	minValue := 1.0
+	desired := []*discordgo.ApplicationCommand{
+		{
+			Name:        "quote",
+			Description: "Interact with quotes",
+			Options: []*discordgo.ApplicationCommandOption{
+				{Name: "random", Description: "Random quote", Type: discordgo.ApplicationCommandOptionSubCommand},
+			},
+		},
+		{
+			Name:        "skip",
+			Description: "Skip current song",
+			Type:        discordgo.ChatApplicationCommand,
+			Options: []*discordgo.ApplicationCommandOption{
+				{Name: "amount", Description: "Amount", Type: discordgo.ApplicationCommandOptionInteger, MinValue: &minValue},
+			},
+		},
+		{
+			Name:        "stop",
+			Description: "Stop audio playback",
+		},
+	}
+
+	registeredMinValue := 1.0
+	registered := []*discordgo.ApplicationCommand{
+		{
+			ID:          "1",
+			Name:        "quote",
+			Type:        discordgo.ChatApplicationCommand,
+			Description: "Interact with quotes",
+			Options: []*discordgo.ApplicationCommandOption{
+				{Name: "random", Description: "Random quote", Type: discordgo.ApplicationCommandOptionSubCommand},
+			},
+		},
+		{
+			ID:          "2",
+			Name:        "skip",
+			Type:        discordgo.ChatApplicationCommand,
+			Description: "Skip current song",
+			Options: []*discordgo.ApplicationCommandOption{
+				{Name: "amount", Description: "Amount of songs", Type: discordgo.ApplicationCommandOptionInteger, MinValue: &registeredMinValue},
+			},
+		},
+		{
+			ID:          "3",
+			Name:        "poll",
+			Type:        discordgo.ChatApplicationCommand,
+			Description: "Interact with polls",
+		},
+	}
+
+	want := commandDiff{
+		Created: []string{"stop"},
+		Updated: []string{"skip"},
+		Removed: []string{"poll"},
+	}
+
+	got := diffCommands(desired, registered)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("wrong diff received. got %+v, expected, %+v", got, want)
+	}
+
+	if diff := diffCommands(registered, registered); !diff.Empty() {
+		t.Fatalf("expected empty diff, got %+v", diff)
+	}
+}
@@ -0,0 +1,161 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/LeBulldoge/gungus/internal/audit"
+	"github.com/LeBulldoge/gungus/internal/discord/embed"
+	"github.com/LeBulldoge/gungus/internal/discord/format"
+	"github.com/LeBulldoge/gungus/internal/discord/i18n"
+	"github.com/LeBulldoge/gungus/internal/permission"
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	entryLimit    = 20
+	payloadLength = 100
+	// the length limit of an embed description
+	descriptionLength = 4096
+)
+
+func (c *Command) showAudit(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	if !permission.CanManage(ctx, intr.Member) {
+		format.DisplayInteractionError(session, intr, i18n.T(intr, "audit.manage_only"))
+		return
+	}
+
+	filter := audit.Filter{GuildID: intr.GuildID, Limit: entryLimit}
+	now := time.Now()
+	for _, opt := range intr.ApplicationCommandData().Options {
+		var err error
+		switch opt.Name {
+		case "user":
+			filter.UserID = opt.UserValue(nil).ID
+		case "command":
+			filter.Command = strings.TrimPrefix(strings.TrimSpace(opt.StringValue()), "/")
+		case "since":
+			filter.Since, err = parseTime(opt.StringValue(), now)
+		case "until":
+			filter.Until, err = parseTime(opt.StringValue(), now)
+		}
+		if err != nil {
+			format.DisplayInteractionError(session, intr, i18n.T(intr, "audit.invalid_time", opt.Name))
+			return
+		}
+	}
+
+	log := c.logger.With("user", filter.UserID, "command", filter.Command, "since", filter.Since, "until", filter.Until)
+
+	entries, err := audit.Query(ctx, c.Storage(), filter)
+	if err != nil {
+		log.Error("failure getting audit entries", "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "audit.error_getting"), err)
+		return
+	}
+
+	var sb strings.Builder
+	for _, e := range entries {
+		sb.WriteString(i18n.T(intr, "audit.entry", format.TimeToTimestamp(e.Date), "<@"+e.UserID+">", e.Command, "<#"+e.ChannelID+">"))
+		sb.WriteRune('\n')
+		if len(e.Payload) > 0 && e.Payload != "{}" && e.Payload != "null" {
+			fmt.Fprintf(&sb, "`%s`\n", strings.ReplaceAll(truncate(e.Payload, payloadLength), "`", "'"))
+		}
+	}
+	if len(entries) == 0 {
+		sb.WriteString(i18n.T(intr, "audit.empty"))
+	}
+
+	builder := embed.NewEmbed().
+		SetTitle(i18n.T(intr, "audit.title")).
+		SetDescription(truncate(sb.String(), descriptionLength))
+	if len(entries) == entryLimit {
+		builder.SetFooter(i18n.T(intr, "audit.truncated", entryLimit), "")
+	}
+
+	err = session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:          []*discordgo.MessageEmbed{builder.MessageEmbed},
+			Flags:           discordgo.MessageFlagsEphemeral,
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		},
+	})
+	if err != nil {
+		log.Error("failure responding to interaction", "err", err)
+	}
+}
+
+func truncate(s string, length int) string {
+	if utf8.RuneCountInString(s) <= length {
+		return s
+	}
+	return string([]rune(s)[:length-1]) + "…"
+}
+
+var errInvalidTime = errors.New("expected a time ago like 30m, 2h or 7d, or a date like 2024-05-01 or 2024-05-01 18:30 (UTC)")
+
+// parseTime reads a point in time as either a duration before now, with d for days,
+// or a UTC date with an optional time of day.
+func parseTime(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return time.Time{}, errInvalidTime
+		}
+		return now.AddDate(0, 0, -n), nil
+	}
+
+	if d, err := time.ParseDuration(s); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+
+	for _, layout := range []string{time.DateOnly, "2006-01-02 15:04", time.DateTime, time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, errInvalidTime
+}
+
+func (c *Command) commandAutocomplete(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	var query string
+	for _, opt := range intr.ApplicationCommandData().Options {
+		if opt.Focused {
+			query = strings.ToLower(opt.StringValue())
+		}
+	}
+
+	commands, err := audit.Commands(ctx, c.Storage(), intr.GuildID)
+	if err != nil {
+		c.logger.Error("failure getting audited commands", "err", err)
+	}
+
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	for _, cmd := range commands {
+		if strings.Contains(cmd, query) && len(choices) < 25 {
+			choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+				Name:  "/" + cmd,
+				Value: cmd,
+			})
+		}
+	}
+
+	err = session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+	if err != nil {
+		c.logger.Error("failure responding to autocomplete", "err", err)
+	}
+}
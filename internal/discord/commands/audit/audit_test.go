@@ -0,0 +1,34 @@
+package audit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"2h", now.Add(-2 * time.Hour), false},
+		{"7d", time.Date(2024, 5, 3, 12, 0, 0, 0, time.UTC), false},
+		{"2024-05-01", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), false},
+		{"2024-05-01 18:30", time.Date(2024, 5, 1, 18, 30, 0, 0, time.UTC), false},
+		{"-2h", time.Time{}, true},
+		{"xd", time.Time{}, true},
+		{"yesterday", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseTime(tt.value, now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("wrong error received for %q. got %v", tt.value, err)
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("wrong time received for %q. got %v, expected, %v", tt.value, got, tt.want)
+		}
+	}
+}
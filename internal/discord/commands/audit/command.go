@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"log/slog"
+
+	"github.com/LeBulldoge/gungus/internal/database"
+	"github.com/LeBulldoge/gungus/internal/discord/bot"
+	"github.com/LeBulldoge/gungus/internal/discord/help"
+	"github.com/bwmarrin/discordgo"
+)
+
+type Command struct {
+	database.WithStorage
+
+	logger *slog.Logger
+}
+
+func NewCommand() *Command {
+	return &Command{}
+}
+
+var manageGuild int64 = discordgo.PermissionManageGuild
+
+func (c *Command) GetSignature() []*discordgo.ApplicationCommand {
+	return []*discordgo.ApplicationCommand{
+		{
+			Name:                     "audit",
+			Description:              "Show who changed what through the bot",
+			DefaultMemberPermissions: &manageGuild,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Name:        "user",
+					Description: "Only show actions of this user",
+					Type:        discordgo.ApplicationCommandOptionUser,
+				},
+				{
+					Name:         "command",
+					Description:  "Only show actions of this command, e.g. movie remove",
+					Type:         discordgo.ApplicationCommandOptionString,
+					Autocomplete: true,
+				},
+				{
+					Name:        "since",
+					Description: "Start of the time range, e.g. 2h, 7d or 2024-05-01",
+					Type:        discordgo.ApplicationCommandOptionString,
+				},
+				{
+					Name:        "until",
+					Description: "End of the time range, e.g. 2h, 7d or 2024-05-01",
+					Type:        discordgo.ApplicationCommandOptionString,
+				},
+			},
+		},
+	}
+}
+
+func (c *Command) GetExamples() []help.Example {
+	return []help.Example{
+		{Command: "audit", Usage: "/audit command:movie remove since:7d", Description: "audit.examples.command"},
+	}
+}
+
+func (c *Command) AddLogger(logger *slog.Logger) {
+	if logger == nil {
+		return
+	}
+
+	c.logger = logger
+}
+
+func (c *Command) Setup(bot *bot.Bot) error {
+	bot.Router.HandleCommand("audit", c.showAudit)
+	bot.Router.HandleAutocomplete("audit", "command", c.commandAutocomplete)
+
+	return nil
+}
+
+func (c *Command) Cleanup(bot *bot.Bot) error {
+	return nil
+}
@@ -1,15 +1,26 @@
 package commands
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 
 	"github.com/LeBulldoge/gungus/internal/database"
 	"github.com/LeBulldoge/gungus/internal/discord/bot"
+	"github.com/LeBulldoge/gungus/internal/discord/commands/audit"
+	"github.com/LeBulldoge/gungus/internal/discord/commands/features"
+	helpcmd "github.com/LeBulldoge/gungus/internal/discord/commands/help"
 	"github.com/LeBulldoge/gungus/internal/discord/commands/movie"
+	"github.com/LeBulldoge/gungus/internal/discord/commands/permissions"
 	"github.com/LeBulldoge/gungus/internal/discord/commands/play"
 	"github.com/LeBulldoge/gungus/internal/discord/commands/poll"
 	"github.com/LeBulldoge/gungus/internal/discord/commands/quote"
+	"github.com/LeBulldoge/gungus/internal/discord/commands/settings"
+	"github.com/LeBulldoge/gungus/internal/discord/commands/status"
+	"github.com/LeBulldoge/gungus/internal/discord/help"
+	"github.com/LeBulldoge/gungus/internal/discord/i18n"
 	"github.com/bwmarrin/discordgo"
 )
 
@@ -23,26 +34,60 @@ type Command interface {
 	AddLogger(*slog.Logger)
 }
 
+var (
+	playCommand = play.NewCommand()
+	helpCommand = helpcmd.NewCommand()
+)
+
 var commands = map[string]Command{
-	"play":  play.NewCommand(),
-	"movie": movie.NewCommand(),
-	"poll":  poll.NewCommand(),
-	"quote": quote.NewCommand(),
+	"play":   playCommand,
+	"movie":  movie.NewCommand(),
+	"poll":   poll.NewCommand(),
+	"quote":  quote.NewCommand(),
+	"status": status.NewCommand(playCommand),
+
+	"permissions": permissions.NewCommand(),
+	"settings":    settings.NewCommand(),
+	"features":    features.NewCommand(),
+	"audit":       audit.NewCommand(),
+	"help":        helpCommand,
 }
 
+// toggleable are the commands guilds can disable as features.
+var toggleable = []string{"play", "movie", "poll", "quote"}
+
 func SetupCommands(bot *bot.Bot) error {
-	botUserID := bot.Session.State.User.ID
+	for _, name := range bot.Config.Commands {
+		if _, ok := commands[name]; !ok {
+			return fmt.Errorf("unknown command %s in configuration", name)
+		}
+	}
+
+	sigs := []*discordgo.ApplicationCommand{}
+	bot.Features = make(map[string][]*discordgo.ApplicationCommand)
 	for name, cmd := range commands {
-		sigs := cmd.GetSignature()
-		for _, sig := range sigs {
-			regCmd, err := bot.Session.ApplicationCommandCreate(
-				botUserID, "", sig,
-			)
-			if err != nil {
-				return fmt.Errorf("failed to register %s: %w", sig.Name, err)
-			}
-			slog.Info("command registered", "command.name", regCmd.Name)
+		if !bot.Config.CommandEnabled(name) {
+			slog.Info("command disabled", "command.name", name)
+			continue
+		}
+
+		cmdSigs := cmd.GetSignature()
+		i18n.Localize(cmdSigs)
+		sigs = append(sigs, cmdSigs...)
+		if slices.Contains(toggleable, name) {
+			bot.Features[name] = cmdSigs
 		}
+		if doc, ok := cmd.(help.Documented); ok {
+			helpCommand.AddExamples(doc.GetExamples()...)
+		}
+	}
+	bot.Commands = sigs
+
+	for name, cmd := range commands {
+		if !bot.Config.CommandEnabled(name) {
+			continue
+		}
+
 		logger := slog.Default().With(
 			slog.Group(
 				"command",
@@ -51,9 +96,73 @@ func SetupCommands(bot *bot.Bot) error {
 		)
 		cmd.AddLogger(logger)
 		cmd.SetStorageConnection(bot.Storage)
-		if err := cmd.Setup(bot); err != nil {
+
+		// Handlers registered by the command are logged with its logger
+		cmdBot := *bot
+		cmdBot.Router = bot.Router.WithLogger(logger)
+		if slices.Contains(toggleable, name) {
+			cmdBot.Router = cmdBot.Router.WithFeature(name)
+		}
+		if err := cmd.Setup(&cmdBot); err != nil {
 			return fmt.Errorf("failed to setup command %s: %w", name, err)
 		}
 	}
+
+	if err := bot.SyncGlobalCommands(); err != nil {
+		return fmt.Errorf("failed to sync global commands: %w", err)
+	}
+
+	watchGuilds(bot.Session, func(guildID string) {
+		if err := bot.SyncGuildCommands(bot.Context(), guildID); err != nil {
+			slog.Error("failed to sync guild commands", "guildId", guildID, "err", err)
+		}
+	})
+
 	return nil
 }
+
+// watchGuilds calls sync for every guild of the bot. Guilds are received after connecting,
+// which happens before the commands are set up, and whenever the bot joins one. So sync is
+// called for those already in the state, and for those received once the handler is added.
+// A guild received in between is synced twice, which does nothing the second time.
+func watchGuilds(session *discordgo.Session, sync func(guildID string)) {
+	session.AddHandler(func(_ *discordgo.Session, g *discordgo.GuildCreate) {
+		sync(g.ID)
+	})
+
+	session.State.RLock()
+	ids := make([]string, 0, len(session.State.Guilds))
+	for _, g := range session.State.Guilds {
+		ids = append(ids, g.ID)
+	}
+	session.State.RUnlock()
+
+	for _, id := range ids {
+		sync(id)
+	}
+}
+
+// CleanupCommands calls Cleanup on every enabled command, giving up when ctx is done.
+func CleanupCommands(ctx context.Context, bot *bot.Bot) error {
+	done := make(chan error, 1)
+	go func() {
+		var errs []error
+		for name, cmd := range commands {
+			if !bot.Config.CommandEnabled(name) {
+				continue
+			}
+
+			if err := cmd.Cleanup(bot); err != nil {
+				errs = append(errs, fmt.Errorf("failed to cleanup command %s: %w", name, err))
+			}
+		}
+		done <- errors.Join(errs...)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("command cleanup timed out: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,131 @@
+package commands
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/LeBulldoge/gungus/internal/discord/help"
+	"github.com/LeBulldoge/gungus/internal/discord/i18n"
+	"github.com/LeBulldoge/gungus/internal/settings"
+	"github.com/bwmarrin/discordgo"
+)
+
+// signatureKeys returns the catalog keys of the descriptions of every command and option,
+// which are required, and the names of every choice and context menu command.
+func signatureKeys() map[string]bool {
+	keys := map[string]bool{}
+	var walk func(path string, opts []*discordgo.ApplicationCommandOption)
+	walk = func(path string, opts []*discordgo.ApplicationCommandOption) {
+		for _, opt := range opts {
+			optPath := path + " " + opt.Name
+			keys["commands."+optPath+".description"] = true
+			for _, choice := range opt.Choices {
+				keys["commands."+optPath+" "+choice.Name+".name"] = false
+			}
+			walk(optPath, opt.Options)
+		}
+	}
+
+	for _, cmd := range commands {
+		for _, sig := range cmd.GetSignature() {
+			if sig.Type == discordgo.ChatApplicationCommand || sig.Type == 0 {
+				keys["commands."+sig.Name+".description"] = true
+			} else {
+				keys["commands."+sig.Name+".name"] = false
+			}
+			walk(sig.Name, sig.Options)
+		}
+	}
+
+	return keys
+}
+
+func TestSignaturesLocalized(t *testing.T) {
+	keys := signatureKeys()
+
+	for _, locale := range i18n.Locales()[1:] {
+		for key, required := range keys {
+			if !required {
+				continue
+			}
+			msg, ok := i18n.Lookup([]discordgo.Locale{locale}, key)
+			if !ok {
+				t.Errorf("missing key %s in locale %s", key, locale)
+			} else if utf8.RuneCountInString(msg) > 100 {
+				t.Errorf("description %s in locale %s is longer than 100 characters", key, locale)
+			}
+		}
+
+		for _, key := range i18n.Keys(locale) {
+			if !strings.HasPrefix(key, "commands.") {
+				continue
+			}
+			path := strings.TrimSuffix(strings.TrimSuffix(key, ".name"), ".description")
+			if _, ok := keys[path+".description"]; !ok {
+				if _, ok := keys[key]; !ok {
+					t.Errorf("key %s in locale %s doesn't match a command signature", key, locale)
+				}
+			}
+		}
+	}
+}
+
+func TestExamples(t *testing.T) {
+	keys := signatureKeys()
+
+	for name, cmd := range commands {
+		doc, ok := cmd.(help.Documented)
+		if !ok {
+			continue
+		}
+		for _, ex := range doc.GetExamples() {
+			if _, ok := keys["commands."+ex.Command+".description"]; !ok {
+				t.Errorf("example of %s is for unknown command %s", name, ex.Command)
+			}
+			if !strings.HasPrefix(ex.Usage, "/"+ex.Command) {
+				t.Errorf("wrong usage received for %s. got %q, expected, to start with %q", ex.Command, ex.Usage, "/"+ex.Command)
+			}
+			if _, ok := i18n.Lookup(nil, ex.Description); !ok {
+				t.Errorf("example of %s has unknown message %s", ex.Command, ex.Description)
+			}
+		}
+	}
+}
+
+func TestSettingsDescribed(t *testing.T) {
+	for _, locale := range i18n.Locales() {
+		for _, def := range settings.All {
+			msg, ok := i18n.Lookup([]discordgo.Locale{locale}, def.Description())
+			if !ok {
+				t.Errorf("missing description of setting %s in locale %s", def.Key(), locale)
+			} else if utf8.RuneCountInString(def.Key()+": "+msg) > 100 {
+				t.Errorf("description of setting %s in locale %s is too long for autocomplete", def.Key(), locale)
+			}
+		}
+	}
+}
+
+func TestWatchGuilds(t *testing.T) {
+	session, err := discordgo.New("Bot token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// guilds received while connecting, before the commands are set up
+	for _, id := range []string{"1", "2"} {
+		if err := session.State.GuildAdd(&discordgo.Guild{ID: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	synced := []string{}
+	watchGuilds(session, func(guildID string) {
+		synced = append(synced, guildID)
+	})
+
+	slices.Sort(synced)
+	if !slices.Equal(synced, []string{"1", "2"}) {
+		t.Fatalf("wrong guilds synced. got %v, expected, %v", synced, []string{"1", "2"})
+	}
+}
@@ -0,0 +1,90 @@
+package features
+
+import (
+	"log/slog"
+	"maps"
+	"slices"
+
+	"github.com/LeBulldoge/gungus/internal/database"
+	"github.com/LeBulldoge/gungus/internal/discord/bot"
+	"github.com/bwmarrin/discordgo"
+)
+
+type Command struct {
+	database.WithStorage
+
+	bot *bot.Bot
+
+	logger *slog.Logger
+}
+
+func NewCommand() *Command {
+	return &Command{}
+}
+
+var manageGuild int64 = discordgo.PermissionManageGuild
+
+func (c *Command) GetSignature() []*discordgo.ApplicationCommand {
+	featureOption := &discordgo.ApplicationCommandOption{
+		Name:         "feature",
+		Description:  "Feature to toggle",
+		Type:         discordgo.ApplicationCommandOptionString,
+		Required:     true,
+		Autocomplete: true,
+	}
+
+	return []*discordgo.ApplicationCommand{
+		{
+			Name:                     "features",
+			Description:              "Enable or disable features of the bot on this server",
+			DefaultMemberPermissions: &manageGuild,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Name:        "list",
+					Description: "Show which features are enabled",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+				},
+				{
+					Name:        "enable",
+					Description: "Enable a feature and its commands",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options:     []*discordgo.ApplicationCommandOption{featureOption},
+				},
+				{
+					Name:        "disable",
+					Description: "Disable a feature and remove its commands",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options:     []*discordgo.ApplicationCommandOption{featureOption},
+				},
+			},
+		},
+	}
+}
+
+func (c *Command) AddLogger(logger *slog.Logger) {
+	if logger == nil {
+		return
+	}
+
+	c.logger = logger
+}
+
+func (c *Command) Setup(bot *bot.Bot) error {
+	c.bot = bot
+
+	bot.Router.HandleCommand("features list", c.listFeatures)
+	bot.Router.HandleCommand("features enable", c.toggleFeature)
+	bot.Router.HandleCommand("features disable", c.toggleFeature)
+	bot.Router.HandleAutocomplete("features enable", "feature", c.featureAutocomplete)
+	bot.Router.HandleAutocomplete("features disable", "feature", c.featureAutocomplete)
+
+	return nil
+}
+
+func (c *Command) Cleanup(bot *bot.Bot) error {
+	return nil
+}
+
+func (c *Command) features() []string {
+	return slices.Sorted(maps.Keys(c.bot.Features))
+}
@@ -0,0 +1,119 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/LeBulldoge/gungus/internal/audit"
+	"github.com/LeBulldoge/gungus/internal/discord/embed"
+	"github.com/LeBulldoge/gungus/internal/discord/format"
+	"github.com/LeBulldoge/gungus/internal/discord/i18n"
+	"github.com/LeBulldoge/gungus/internal/permission"
+	"github.com/LeBulldoge/gungus/internal/settings"
+	"github.com/bwmarrin/discordgo"
+)
+
+func (c *Command) listFeatures(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	var sb strings.Builder
+	for _, feature := range c.features() {
+		status := i18n.T(intr, "features.disabled")
+		if settings.FeatureEnabled(ctx, c.Storage(), intr.GuildID, feature) {
+			status = i18n.T(intr, "features.enabled")
+		}
+		fmt.Fprintf(&sb, "`%s` %s\n", feature, status)
+	}
+
+	err := session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{
+				embed.NewEmbed().SetTitle(i18n.T(intr, "features.title")).SetDescription(sb.String()).MessageEmbed,
+			},
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		c.logger.Error("failure responding to interaction", "err", err)
+	}
+}
+
+func (c *Command) toggleFeature(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	if !permission.CanManage(ctx, intr.Member) {
+		format.DisplayInteractionError(session, intr, i18n.T(intr, "features.manage_only"))
+		return
+	}
+
+	opt := intr.ApplicationCommandData().Options[0]
+	enable := opt.Name == "enable"
+	feature := opt.Options[0].StringValue()
+
+	log := c.logger.With("feature", feature, "enable", enable)
+
+	if !slices.Contains(c.features(), feature) {
+		format.DisplayInteractionError(session, intr, i18n.T(intr, "features.unknown", feature))
+		return
+	}
+
+	// Syncing guild commands can take a while
+	err := session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Error("failure responding to interaction", "err", err)
+		return
+	}
+
+	err = settings.SetFeatureEnabled(ctx, c.Storage(), intr.GuildID, feature, enable)
+	if err != nil {
+		log.Error("failure saving feature state", "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "features.error_saving"), err)
+		return
+	}
+	if err := audit.Record(ctx, c.Storage(), intr, "features "+opt.Name, audit.Payload{"feature": feature}); err != nil {
+		log.Error("failure recording audit entry", "err", err)
+	}
+
+	err = c.bot.SyncGuildCommands(ctx, intr.GuildID)
+	if err != nil {
+		log.Error("failure syncing guild commands", "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "features.error_syncing"), err)
+		return
+	}
+
+	content := i18n.T(intr, "features.enabled_done", feature)
+	if !enable {
+		content = i18n.T(intr, "features.disabled_done", feature)
+	}
+	_, err = session.InteractionResponseEdit(intr.Interaction, &discordgo.WebhookEdit{Content: &content})
+	if err != nil {
+		log.Error("failure editing response", "err", err)
+	}
+
+	log.Info("feature toggled")
+}
+
+func (c *Command) featureAutocomplete(_ context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	query := strings.ToLower(intr.ApplicationCommandData().Options[0].Options[0].StringValue())
+
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	for _, feature := range c.features() {
+		if strings.Contains(feature, query) {
+			choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: feature, Value: feature})
+		}
+	}
+
+	err := session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+	if err != nil {
+		c.logger.Error("failure responding to autocomplete", "err", err)
+	}
+}
@@ -0,0 +1,72 @@
+package help
+
+import (
+	"log/slog"
+
+	"github.com/LeBulldoge/gungus/internal/database"
+	"github.com/LeBulldoge/gungus/internal/discord/bot"
+	"github.com/LeBulldoge/gungus/internal/discord/help"
+	"github.com/bwmarrin/discordgo"
+)
+
+const pageNamespace = "help"
+
+type Command struct {
+	database.WithStorage
+
+	commands []*discordgo.ApplicationCommand
+	features map[string][]*discordgo.ApplicationCommand
+	examples []help.Example
+
+	logger *slog.Logger
+}
+
+func NewCommand() *Command {
+	return &Command{}
+}
+
+// AddExamples attaches usage examples to the help of their commands.
+func (c *Command) AddExamples(examples ...help.Example) {
+	c.examples = append(c.examples, examples...)
+}
+
+func (c *Command) GetSignature() []*discordgo.ApplicationCommand {
+	return []*discordgo.ApplicationCommand{
+		{
+			Name:        "help",
+			Description: "Show how to use the commands of the bot",
+			Type:        discordgo.ChatApplicationCommand,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Name:         "command",
+					Description:  "Command to show the details of",
+					Type:         discordgo.ApplicationCommandOptionString,
+					Autocomplete: true,
+				},
+			},
+		},
+	}
+}
+
+func (c *Command) AddLogger(logger *slog.Logger) {
+	if logger == nil {
+		return
+	}
+
+	c.logger = logger
+}
+
+func (c *Command) Setup(bot *bot.Bot) error {
+	c.commands = bot.Commands
+	c.features = bot.Features
+
+	bot.Router.HandleCommand("help", c.showHelp)
+	bot.Router.HandleAutocomplete("help", "command", c.commandAutocomplete)
+	bot.Router.WithCommand("help").HandleComponent(pageNamespace, c.paginate)
+
+	return nil
+}
+
+func (c *Command) Cleanup(bot *bot.Bot) error {
+	return nil
+}
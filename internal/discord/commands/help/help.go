@@ -0,0 +1,191 @@
+package help
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/LeBulldoge/gungus/internal/discord/embed"
+	"github.com/LeBulldoge/gungus/internal/discord/format"
+	"github.com/LeBulldoge/gungus/internal/discord/help"
+	"github.com/LeBulldoge/gungus/internal/discord/i18n"
+	"github.com/LeBulldoge/gungus/internal/discord/router"
+	"github.com/LeBulldoge/gungus/internal/settings"
+	"github.com/bwmarrin/discordgo"
+)
+
+func (c *Command) showHelp(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	var name string
+	for _, opt := range intr.ApplicationCommandData().Options {
+		if opt.Name == "command" {
+			name = strings.TrimPrefix(strings.TrimSpace(opt.StringValue()), "/")
+		}
+	}
+
+	pages, root, ok := c.pages(ctx, intr, name)
+	if !ok {
+		format.DisplayInteractionError(session, intr, i18n.T(intr, "help.unknown_command", name))
+		return
+	}
+
+	err := session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: pageResponse(intr, root, pages, 0),
+	})
+	if err != nil {
+		c.logger.Error("failure responding to interaction", "err", err)
+	}
+}
+
+func (c *Command) paginate(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	customID := router.ParseCustomID(intr.MessageComponentData().CustomID)
+	name := customID.Arg(0)
+	index, err := strconv.Atoi(customID.Arg(1))
+	if err != nil {
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "help.error_paginating"), fmt.Errorf("failure reading the page index: %w", err))
+		return
+	}
+
+	pages, root, ok := c.pages(ctx, intr, name)
+	if !ok {
+		format.DisplayInteractionError(session, intr, i18n.T(intr, "help.unknown_command", name))
+		return
+	}
+	index = min(max(index, 0), len(pages)-1)
+
+	err = session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: pageResponse(intr, root, pages, index),
+	})
+	if err != nil {
+		c.logger.Error("failure responding to interaction", "err", err)
+	}
+}
+
+// pages renders the help of the command name, or of every command after an overview if it's empty.
+// It also returns the name of the root command it found, empty for every command.
+func (c *Command) pages(ctx context.Context, intr *discordgo.InteractionCreate, name string) ([]help.Page, string, bool) {
+	chain := i18n.Chain(intr)
+	cmds := c.visibleCommands(ctx, intr.GuildID)
+
+	if len(name) == 0 {
+		pages := []help.Page{help.Overview(chain, cmds)}
+		for _, cmd := range cmds {
+			pages = append(pages, help.Pages(chain, cmd, c.examples)...)
+		}
+		return pages, "", true
+	}
+
+	root, _, _ := strings.Cut(name, " ")
+	for _, cmd := range cmds {
+		if cmd.Name == root {
+			return help.Pages(chain, cmd, c.examples), cmd.Name, true
+		}
+	}
+
+	return nil, "", false
+}
+
+// visibleCommands returns the commands of the bot, sorted by name, without those of
+// features disabled in the guild.
+func (c *Command) visibleCommands(ctx context.Context, guildID string) []*discordgo.ApplicationCommand {
+	hidden := map[*discordgo.ApplicationCommand]bool{}
+	if len(guildID) > 0 {
+		for feature, sigs := range c.features {
+			if !settings.FeatureEnabled(ctx, c.Storage(), guildID, feature) {
+				for _, sig := range sigs {
+					hidden[sig] = true
+				}
+			}
+		}
+	}
+
+	cmds := []*discordgo.ApplicationCommand{}
+	for _, cmd := range c.commands {
+		if !hidden[cmd] {
+			cmds = append(cmds, cmd)
+		}
+	}
+	slices.SortFunc(cmds, func(a, b *discordgo.ApplicationCommand) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return cmds
+}
+
+// pageResponse shows the page at index of the help of the root command name. Only the
+// resolved name is kept in the custom IDs of the buttons, to stay within their length limit.
+func pageResponse(intr *discordgo.InteractionCreate, name string, pages []help.Page, index int) *discordgo.InteractionResponseData {
+	page := pages[index]
+	builder := embed.NewEmbed().
+		SetTitle(page.Title).
+		SetDescription(page.Description)
+
+	data := &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{builder.MessageEmbed},
+		Flags:  discordgo.MessageFlagsEphemeral,
+	}
+	if len(pages) == 1 {
+		return data
+	}
+
+	builder.SetFooter(i18n.T(intr, "help.page", index+1, len(pages)), "")
+	data.Components = []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					CustomID: router.NewCustomID(pageNamespace, name, strconv.Itoa(index-1)).String(),
+					Emoji: &discordgo.ComponentEmoji{
+						Name: "⬅️",
+					},
+					Style:    discordgo.SecondaryButton,
+					Disabled: index == 0,
+				},
+				discordgo.Button{
+					CustomID: router.NewCustomID(pageNamespace, name, strconv.Itoa(index+1)).String(),
+					Emoji: &discordgo.ComponentEmoji{
+						Name: "➡️",
+					},
+					Style:    discordgo.SecondaryButton,
+					Disabled: index == len(pages)-1,
+				},
+			},
+		},
+	}
+
+	return data
+}
+
+func (c *Command) commandAutocomplete(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	var query string
+	for _, opt := range intr.ApplicationCommandData().Options {
+		if opt.Focused {
+			query = strings.ToLower(strings.TrimPrefix(opt.StringValue(), "/"))
+		}
+	}
+
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	for _, cmd := range c.visibleCommands(ctx, intr.GuildID) {
+		if cmd.Type != discordgo.ChatApplicationCommand && cmd.Type != 0 {
+			continue
+		}
+		if strings.Contains(cmd.Name, query) && len(choices) < 25 {
+			choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+				Name:  "/" + cmd.Name,
+				Value: cmd.Name,
+			})
+		}
+	}
+
+	err := session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+	if err != nil {
+		c.logger.Error("failure responding to autocomplete", "err", err)
+	}
+}
@@ -2,10 +2,10 @@ package movie
 
 import (
 	"log/slog"
-	"strings"
 
 	"github.com/LeBulldoge/gungus/internal/database"
 	"github.com/LeBulldoge/gungus/internal/discord/bot"
+	"github.com/LeBulldoge/gungus/internal/discord/help"
 	"github.com/bwmarrin/discordgo"
 )
 
@@ -59,7 +59,7 @@ func (c *Command) GetSignature() []*discordgo.ApplicationCommand {
 						},
 						{
 							Name:        "rating",
-							Description: "Rating of the movie from -10.0 to 10.0",
+							Description: "Rating of the movie, from -10.0 to 10.0 unless changed in /settings",
 							Type:        discordgo.ApplicationCommandOptionNumber,
 							Required:    true,
 						},
@@ -105,36 +105,24 @@ func (c *Command) GetSignature() []*discordgo.ApplicationCommand {
 	}
 }
 
+const listNamespace = "movielist"
+
 func (c *Command) Setup(bot *bot.Bot) error {
-	bot.Session.AddHandler(func(sesh *discordgo.Session, intr *discordgo.InteractionCreate) {
-		switch intr.Type {
-		case discordgo.InteractionApplicationCommand:
-			fallthrough
-		case discordgo.InteractionApplicationCommandAutocomplete:
-			data := intr.ApplicationCommandData()
-			if data.Name != "movie" {
-				return
-			}
-			subData := intr.ApplicationCommandData().Options[0]
-			switch subData.Name {
-			case "add":
-				c.addMovie(sesh, intr)
-			case "list":
-				c.movieList(sesh, intr)
-			case "rate":
-				c.rateMovie(sesh, intr)
-			case "remove":
-				c.movieDelete(sesh, intr)
-			case "cast":
-				c.addUserAsCastMember(sesh, intr)
-			}
-		case discordgo.InteractionMessageComponent:
-			customID := intr.MessageComponentData().CustomID
-			if strings.HasPrefix(customID, "movielist") {
-				c.movieListPaginate(sesh, intr)
-			}
-		}
-	})
+	bot.Router.HandleCommand("movie add", c.addMovie)
+	bot.Router.HandleAutocomplete("movie add", "title", c.addMovieAutocomplete)
+
+	bot.Router.HandleCommand("movie list", c.movieList)
+	bot.Router.WithCommand("movie list").HandleComponent(listNamespace, c.movieListPaginate)
+
+	bot.Router.HandleCommand("movie rate", c.rateMovie)
+	bot.Router.HandleAutocomplete("movie rate", "title", c.movieTitleAutocomplete)
+
+	bot.Router.HandleCommand("movie remove", c.movieDelete)
+	bot.Router.HandleAutocomplete("movie remove", "title", c.movieTitleAutocomplete)
+
+	bot.Router.HandleCommand("movie cast", c.addUserAsCastMember)
+	bot.Router.HandleAutocomplete("movie cast", "title", c.movieTitleAutocomplete)
+	bot.Router.HandleAutocomplete("movie cast", "character", c.movieCharacterAutocomplete)
 
 	return nil
 }
@@ -143,6 +131,14 @@ func (c *Command) Cleanup(bot *bot.Bot) error {
 	return nil
 }
 
+func (c *Command) GetExamples() []help.Example {
+	return []help.Example{
+		{Command: "movie add", Usage: "/movie add title:Alien", Description: "movie.examples.add"},
+		{Command: "movie rate", Usage: "/movie rate title:Alien rating:8.5", Description: "movie.examples.rate"},
+		{Command: "movie cast", Usage: "/movie cast title:Alien character:Ripley", Description: "movie.examples.cast"},
+	}
+}
+
 func (c *Command) AddLogger(logger *slog.Logger) {
 	if logger == nil {
 		return
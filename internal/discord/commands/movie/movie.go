@@ -4,95 +4,104 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"math"
 	"strconv"
-	"strings"
 	"time"
 
+	"github.com/LeBulldoge/gungus/internal/audit"
 	"github.com/LeBulldoge/gungus/internal/discord/embed"
 	"github.com/LeBulldoge/gungus/internal/discord/format"
+	"github.com/LeBulldoge/gungus/internal/discord/i18n"
+	"github.com/LeBulldoge/gungus/internal/discord/router"
+	"github.com/LeBulldoge/gungus/internal/permission"
+	"github.com/LeBulldoge/gungus/internal/settings"
 	"github.com/bwmarrin/discordgo"
 )
 
-func (c *Command) addMovie(session *discordgo.Session, intr *discordgo.InteractionCreate) {
+func (c *Command) addMovie(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
 	opt := intr.ApplicationCommandData().Options[0]
 
-	switch intr.Type {
-	case discordgo.InteractionApplicationCommand:
-		movieID := opt.Options[0].StringValue()
-		log := c.logger.With(
-			slog.Group(
-				"add",
-				"movieId", movieID,
-			),
-		)
-
-		err := AddMovie(
-			context.TODO(),
-			c.Storage(),
-			movieID,
-			intr.Member.User.ID,
-			time.Now(),
-		)
-		if err != nil {
-			log.Error("error adding a movie", "err", err)
-			format.DisplayInteractionWithError(session, intr, "Error adding a movie.", err)
-			return
-		}
+	movieID := opt.Options[0].StringValue()
+	log := c.logger.With(
+		slog.Group(
+			"add",
+			"movieId", movieID,
+		),
+	)
 
-		response, err := c.buildResponseWithMovieEmbed(session, intr, movieID)
-		if err != nil {
-			log.Error("error displaying added movie", "err", err)
-			format.DisplayInteractionWithError(session, intr, "Error displaying added movie.", err)
-			return
-		}
-		response.Data.Content = "New movie added!"
+	err := AddMovie(
+		ctx,
+		c.Storage(),
+		intr.GuildID,
+		movieID,
+		intr.Member.User.ID,
+		time.Now(),
+	)
+	if err != nil {
+		log.Error("error adding a movie", "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "movie.error_adding"), err)
+		return
+	}
+	if err := audit.Record(ctx, c.Storage(), intr, "movie add", audit.Payload{"movieId": movieID}); err != nil {
+		log.Error("failure recording audit entry", "err", err)
+	}
 
-		err = session.InteractionRespond(intr.Interaction, response)
-		if err != nil {
-			c.logger.Error("error responding to request", "err", err)
-			return
-		}
+	response, err := c.buildResponseWithMovieEmbed(ctx, session, intr, movieID)
+	if err != nil {
+		log.Error("error displaying added movie", "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "movie.error_displaying_added"), err)
+		return
+	}
+	response.Data.Content = i18n.T(intr, "movie.added")
 
-		log.Info("movie added")
-	case discordgo.InteractionApplicationCommandAutocomplete:
-		opt := intr.ApplicationCommandData().Options[0]
-		movieID := opt.Options[0].StringValue()
-		log := c.logger.With(
-			slog.Group(
-				"add/autocomplete",
-				"movieId", movieID,
-			),
-		)
-
-		movies, err := SearchMovies(movieID)
-		if err != nil {
-			log.Error("error searching movies", "err", err)
-			format.DisplayInteractionWithError(session, intr, "Error searching movies.", err)
-			return
-		}
+	err = session.InteractionRespond(intr.Interaction, response)
+	if err != nil {
+		c.logger.Error("error responding to request", "err", err)
+		return
+	}
 
-		choices := []*discordgo.ApplicationCommandOptionChoice{}
-		for _, movie := range movies {
-			choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
-				Name:  movie.Title,
-				Value: movie.ID,
-			})
-		}
+	log.Info("movie added")
+}
 
-		err = session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionApplicationCommandAutocompleteResult,
-			Data: &discordgo.InteractionResponseData{
-				Choices: choices,
-			},
+func (c *Command) addMovieAutocomplete(_ context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	opt := intr.ApplicationCommandData().Options[0]
+	movieID := opt.Options[0].StringValue()
+	log := c.logger.With(
+		slog.Group(
+			"add/autocomplete",
+			"movieId", movieID,
+		),
+	)
+
+	movies, err := SearchMovies(movieID)
+	if err != nil {
+		log.Error("error searching movies", "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "movie.error_searching"), err)
+		return
+	}
+
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	for _, movie := range movies {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  movie.Title,
+			Value: movie.ID,
 		})
-		if err != nil {
-			log.Error("error responding to request", "err", err)
-		}
+	}
+
+	err = session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+	if err != nil {
+		log.Error("error responding to request", "err", err)
 	}
 }
 
-func embedFromMovie(session *discordgo.Session, guildID string, movie Movie) (*discordgo.MessageEmbed, error) {
+// embedFromMovie builds the embed of a movie in the language of the guild of intr, since everyone sees it.
+func embedFromMovie(session *discordgo.Session, intr *discordgo.InteractionCreate, movie Movie) (*discordgo.MessageEmbed, error) {
+	guildID := intr.GuildID
+
 	user, err := session.GuildMember(guildID, movie.AddedBy)
 	if err != nil {
 		return nil, err
@@ -101,18 +110,18 @@ func embedFromMovie(session *discordgo.Session, guildID string, movie Movie) (*d
 	embed := embed.NewEmbed()
 
 	if len(movie.Cast) > 0 {
-		embed.AddField("--- Cast ---", "")
+		embed.AddField(i18n.TGuild(intr, "movie.cast"), "")
 		for _, castMember := range movie.Cast {
 			user, err := session.GuildMember(guildID, castMember.UserID)
 			if err != nil {
 				return nil, err
 			}
-			embed.AddInlineField(castMember.Character, "by "+format.GetMemberDisplayName(user))
+			embed.AddInlineField(castMember.Character, i18n.TGuild(intr, "movie.by", format.GetMemberDisplayName(user)))
 		}
 	}
 	embed.AddField("\u200b", "\u200b")
 	if len(movie.Ratings) > 0 {
-		embed.AddField("--- Ratings ---", "")
+		embed.AddField(i18n.TGuild(intr, "movie.ratings"), "")
 		for _, rating := range movie.Ratings {
 			user, err := session.GuildMember(guildID, rating.UserID)
 			if err != nil {
@@ -120,7 +129,7 @@ func embedFromMovie(session *discordgo.Session, guildID string, movie Movie) (*d
 			}
 			embed.AddInlineField(
 				strconv.FormatFloat(rating.Rating, 'f', 2, 64),
-				"by "+format.GetMemberDisplayName(user),
+				i18n.TGuild(intr, "movie.by", format.GetMemberDisplayName(user)),
 			)
 		}
 	}
@@ -130,15 +139,15 @@ func embedFromMovie(session *discordgo.Session, guildID string, movie Movie) (*d
 		SetUrl(movie.GetURL()).
 		SetDescription(movie.Description).
 		SetImage(movie.Image).
-		SetFooter("Added by "+format.GetMemberDisplayName(user), "").
+		SetFooter(i18n.TGuild(intr, "movie.added_by", format.GetMemberDisplayName(user)), "").
 		SetTimestamp(movie.WatchedOn.Format(time.RFC3339)).
 		MessageEmbed
 
 	return res, nil
 }
 
-func (c *Command) movieList(session *discordgo.Session, intr *discordgo.InteractionCreate) {
-	movies, err := GetMovies(context.TODO(), c.Storage())
+func (c *Command) movieList(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	movies, err := GetMovies(ctx, c.Storage(), intr.GuildID)
 	log := c.logger.With(
 		slog.Group(
 			"list",
@@ -148,21 +157,21 @@ func (c *Command) movieList(session *discordgo.Session, intr *discordgo.Interact
 
 	if err != nil {
 		log.Error("error getting movies", "err", err)
-		format.DisplayInteractionWithError(session, intr, "Error getting movies.", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "movie.error_getting_list"), err)
 		return
 	}
 
 	if len(movies) == 0 {
 		log.Error("movie list is empty")
-		format.DisplayInteractionError(session, intr, "Movie list is empty! You can add movies via the `/movie add` command.")
+		format.DisplayInteractionError(session, intr, i18n.T(intr, "movie.empty_list"))
 		return
 	}
 
 	movie := movies[0]
-	embed, err := embedFromMovie(session, intr.GuildID, movie)
+	embed, err := embedFromMovie(session, intr, movie)
 	if err != nil {
 		log.Error("failure constructing embed from movie", "err", err)
-		format.DisplayInteractionWithError(session, intr, "Error displaying movie.", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "movie.error_displaying"), err)
 		return
 	}
 
@@ -178,21 +187,21 @@ func (c *Command) movieList(session *discordgo.Session, intr *discordgo.Interact
 				discordgo.ActionsRow{
 					Components: []discordgo.MessageComponent{
 						discordgo.Button{
-							CustomID: "movielist_" + intr.ID + "_back",
+							CustomID: router.NewCustomID(listNamespace, "back").String(),
 							Emoji: &discordgo.ComponentEmoji{
 								Name: "⬅️",
 							},
 							Style: discordgo.SecondaryButton,
 						},
 						discordgo.Button{
-							CustomID: "movielist_" + intr.ID + "_forward",
+							CustomID: router.NewCustomID(listNamespace, "forward").String(),
 							Emoji: &discordgo.ComponentEmoji{
 								Name: "➡️",
 							},
 							Style: discordgo.SecondaryButton,
 						},
 						discordgo.Button{
-							CustomID: "movielist_" + intr.ID + "_refresh",
+							CustomID: router.NewCustomID(listNamespace, "refresh").String(),
 							Emoji: &discordgo.ComponentEmoji{
 								Name: "🔄",
 							},
@@ -209,7 +218,7 @@ func (c *Command) movieList(session *discordgo.Session, intr *discordgo.Interact
 	}
 }
 
-func (c *Command) movieListPaginate(session *discordgo.Session, intr *discordgo.InteractionCreate) {
+func (c *Command) movieListPaginate(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
 	log := c.logger.WithGroup("list/paginate").With(
 		"messageId", intr.Message.ID,
 	)
@@ -222,10 +231,10 @@ func (c *Command) movieListPaginate(session *discordgo.Session, intr *discordgo.
 		return
 	}
 
-	movies, err := GetMovies(context.TODO(), c.Storage())
+	movies, err := GetMovies(ctx, c.Storage(), intr.GuildID)
 	if err != nil {
 		log.Error("error getting a movie", "err", err)
-		format.DisplayInteractionWithError(session, intr, "Error getting movie.", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "movie.error_getting"), err)
 		return
 	}
 
@@ -234,17 +243,17 @@ func (c *Command) movieListPaginate(session *discordgo.Session, intr *discordgo.
 	intrMessage, err := session.ChannelMessage(intr.ChannelID, intr.Message.ID)
 	if err != nil {
 		log.Error("error getting interaction message", "err", err)
-		format.DisplayInteractionError(session, intr, fmt.Sprintf("error retrieving interaction message: %v", err))
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "movie.error_retrieving_message"), err)
 		return
 	}
 	if len(intrMessage.Embeds) < 1 {
-		format.DisplayInteractionError(session, intr, fmt.Sprintf("error retrieving last embed: no embeds in message: %s", intrMessage.ID))
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "movie.error_retrieving_message"), fmt.Errorf("no embeds in message %s", intrMessage.ID))
 		return
 	}
 
 	lastIndex, err := strconv.Atoi(intrMessage.Embeds[0].Author.Name)
 	if err != nil {
-		format.DisplayInteractionError(session, intr, fmt.Sprintf("error retrieving last embed: %v", err))
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "movie.error_retrieving_message"), fmt.Errorf("failure reading the index of the last embed: %w", err))
 		return
 	}
 
@@ -252,8 +261,9 @@ func (c *Command) movieListPaginate(session *discordgo.Session, intr *discordgo.
 
 	var index int
 
-	customIDSplit := strings.Split(intr.MessageComponentData().CustomID, "_")
-	dir := customIDSplit[len(customIDSplit)-1]
+	// Legacy custom IDs were "movielist_<interaction id>_<direction>"
+	customID := router.ParseCustomID(intr.MessageComponentData().CustomID)
+	dir := customID.Arg(len(customID.Args) - 1)
 
 	switch dir {
 	case "forward":
@@ -271,17 +281,17 @@ func (c *Command) movieListPaginate(session *discordgo.Session, intr *discordgo.
 	case "refresh":
 		index = lastIndex
 	default:
-		format.DisplayInteractionError(session, intr, fmt.Sprintf("error parsing movie list direction: %s", dir))
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "movie.error_retrieving_message"), fmt.Errorf("unknown movie list direction %q", dir))
 		return
 	}
 
 	movie := movies[index]
 	log = log.With("nextMovieIndex", index, "nextMovieTitle", movie.Title)
 
-	embed, err := embedFromMovie(session, intr.GuildID, movie)
+	embed, err := embedFromMovie(session, intr, movie)
 	if err != nil {
 		log.Error("error creating embed for movie", "err", err)
-		format.DisplayInteractionWithError(session, intr, "Error displaying movie.", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "movie.error_displaying"), err)
 		return
 	}
 	embed.Author = &discordgo.MessageEmbedAuthor{
@@ -295,13 +305,13 @@ func (c *Command) movieListPaginate(session *discordgo.Session, intr *discordgo.
 	}
 }
 
-func (c *Command) buildResponseWithMovieEmbed(session *discordgo.Session, intr *discordgo.InteractionCreate, movieID string) (*discordgo.InteractionResponse, error) {
-	movie, err := GetMovie(context.TODO(), c.Storage(), movieID)
+func (c *Command) buildResponseWithMovieEmbed(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate, movieID string) (*discordgo.InteractionResponse, error) {
+	movie, err := GetMovie(ctx, c.Storage(), intr.GuildID, movieID)
 	if err != nil {
 		return nil, fmt.Errorf("failure getting a movie: %w", err)
 	}
 
-	embed, err := embedFromMovie(session, intr.GuildID, movie)
+	embed, err := embedFromMovie(session, intr, movie)
 	if err != nil {
 		return nil, fmt.Errorf("failure building an embed: %w", err)
 	}
@@ -314,87 +324,106 @@ func (c *Command) buildResponseWithMovieEmbed(session *discordgo.Session, intr *
 	}, nil
 }
 
-func (c *Command) rateMovie(session *discordgo.Session, intr *discordgo.InteractionCreate) {
+func (c *Command) rateMovie(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
 	opt := intr.ApplicationCommandData().Options[0]
 
-	switch intr.Type {
-	case discordgo.InteractionApplicationCommand:
-		movieID := opt.Options[0].StringValue()
-		rating := opt.Options[1].FloatValue()
+	movieID := opt.Options[0].StringValue()
+	rating := opt.Options[1].FloatValue()
 
-		log := c.logger.WithGroup("rate").With("movieId", movieID, "rating", rating)
+	log := c.logger.WithGroup("rate").With("movieId", movieID, "rating", rating)
 
-		if math.Abs(rating) > 10.0 {
-			log.Error("incorrect rating value")
-			format.DisplayInteractionError(session, intr, "Incorrect rating value, must be within -10 to 10.")
-			return
-		}
+	minRating := settings.MovieRatingMin.Get(ctx, c.Storage(), intr.GuildID)
+	maxRating := settings.MovieRatingMax.Get(ctx, c.Storage(), intr.GuildID)
+	if rating < minRating || rating > maxRating {
+		log.Error("incorrect rating value")
+		format.DisplayInteractionError(session, intr, i18n.T(intr, "movie.invalid_rating", minRating, maxRating))
+		return
+	}
 
-		err := RateMovie(context.TODO(), c.Storage(), movieID, intr.Member.User.ID, rating)
-		if err != nil {
-			log.Error("failure rating a movie", "err", err)
-			format.DisplayInteractionWithError(session, intr, "Error rating movie.", err)
-			return
-		}
+	err := RateMovie(ctx, c.Storage(), intr.GuildID, movieID, intr.Member.User.ID, rating)
+	if err != nil {
+		log.Error("failure rating a movie", "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "movie.error_rating"), err)
+		return
+	}
+	if err := audit.Record(ctx, c.Storage(), intr, "movie rate", audit.Payload{"movieId": movieID, "rating": rating}); err != nil {
+		log.Error("failure recording audit entry", "err", err)
+	}
 
-		response, err := c.buildResponseWithMovieEmbed(session, intr, movieID)
-		if err != nil {
-			log.Error("failure displaying a movie", "err", err)
-			format.DisplayInteractionError(session, intr, "Failure displaying movie.")
-			return
-		}
-		response.Data.Flags = discordgo.MessageFlagsEphemeral
-		response.Data.Content = "Movie rated!"
+	response, err := c.buildResponseWithMovieEmbed(ctx, session, intr, movieID)
+	if err != nil {
+		log.Error("failure displaying a movie", "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "movie.error_displaying"), err)
+		return
+	}
+	response.Data.Flags = discordgo.MessageFlagsEphemeral
+	response.Data.Content = i18n.T(intr, "movie.rated")
 
-		err = session.InteractionRespond(intr.Interaction, response)
-		if err != nil {
-			log.Error("error responding to request", "err", err)
-		}
-	case discordgo.InteractionApplicationCommandAutocomplete:
-		err := c.moveListAutocomplete(session, intr)
-		if err != nil {
-			c.logger.Error("failure providing autocompletion for movie/rate", "err", err)
-		}
+	err = session.InteractionRespond(intr.Interaction, response)
+	if err != nil {
+		log.Error("error responding to request", "err", err)
 	}
 }
 
-func (c *Command) movieDelete(session *discordgo.Session, intr *discordgo.InteractionCreate) {
+func (c *Command) movieDelete(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
 	opt := intr.ApplicationCommandData().Options[0]
 
-	switch intr.Type {
-	case discordgo.InteractionApplicationCommand:
-		movieID := opt.Options[0].StringValue()
-		log := c.logger.WithGroup("delete").With("movieId", movieID)
+	movieID := opt.Options[0].StringValue()
+	log := c.logger.WithGroup("delete").With("movieId", movieID)
 
-		err := DeleteMovie(context.TODO(), c.Storage(), movieID)
-		if err != nil {
-			log.Error("failure deleting movie", "err", err)
-			format.DisplayInteractionWithError(session, intr, "Error deleting movie.", err)
-			return
-		}
+	movie, err := GetMovie(ctx, c.Storage(), intr.GuildID, movieID)
+	if err != nil {
+		log.Error("failure getting movie", "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "movie.error_deleting"), err)
+		return
+	}
 
-		err = session.InteractionRespond(intr.Interaction,
-			&discordgo.InteractionResponse{
-				Type: discordgo.InteractionResponseChannelMessageWithSource,
-				Data: &discordgo.InteractionResponseData{
-					Content: fmt.Sprintf("Movie `%s` successfully deleted!", movieID),
-				},
-			})
-		if err != nil {
-			log.Error("error responding to request", "err", err)
-		}
-	case discordgo.InteractionApplicationCommandAutocomplete:
-		err := c.moveListAutocomplete(session, intr)
-		if err != nil {
-			c.logger.Error("failure providing autocompletion for movie/rate", "err", err)
-		}
+	if movie.AddedBy != intr.Member.User.ID && !permission.CanModerate(ctx, intr.Member) {
+		log.Info("user is neither the adder nor a moderator", "user", intr.Member.User.ID)
+		format.DisplayInteractionError(session, intr, i18n.T(intr, "movie.delete_not_allowed"))
+		return
+	}
+
+	err = DeleteMovie(ctx, c.Storage(), intr.GuildID, movieID)
+	if err != nil {
+		log.Error("failure deleting movie", "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "movie.error_deleting"), err)
+		return
+	}
+	if err := audit.Record(ctx, c.Storage(), intr, "movie remove", audit.Payload{"movieId": movieID, "title": movie.Title, "addedBy": movie.AddedBy}); err != nil {
+		log.Error("failure recording audit entry", "err", err)
+	}
+
+	err = session.InteractionRespond(intr.Interaction,
+		&discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: i18n.T(intr, "movie.deleted", movieID),
+			},
+		})
+	if err != nil {
+		log.Error("error responding to request", "err", err)
+	}
+}
+
+func (c *Command) movieTitleAutocomplete(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	err := c.moveListAutocomplete(ctx, session, intr)
+	if err != nil {
+		c.logger.Error("failure providing autocompletion for movie title", "err", err)
+	}
+}
+
+func (c *Command) movieCharacterAutocomplete(_ context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	err := c.movieCastAutocomplete(session, intr)
+	if err != nil {
+		c.logger.Error("failure providing autocompletion for movie/cast/character", "err", err)
 	}
 }
 
-func (c *Command) moveListAutocomplete(session *discordgo.Session, intr *discordgo.InteractionCreate) error {
+func (c *Command) moveListAutocomplete(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) error {
 	opt := intr.ApplicationCommandData().Options[0]
 	title := opt.Options[0].StringValue()
-	movies, err := GetMoviesByTitle(context.TODO(), c.Storage(), title)
+	movies, err := GetMoviesByTitle(ctx, c.Storage(), intr.GuildID, title)
 	if err != nil {
 		return fmt.Errorf("failure getting movies by title: %w", err)
 	}
@@ -452,45 +481,33 @@ func (c *Command) movieCastAutocomplete(session *discordgo.Session, intr *discor
 	return nil
 }
 
-func (c *Command) addUserAsCastMember(session *discordgo.Session, intr *discordgo.InteractionCreate) {
+func (c *Command) addUserAsCastMember(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
 	opt := intr.ApplicationCommandData().Options[0]
 
-	switch intr.Type {
-	case discordgo.InteractionApplicationCommand:
-		movieID := opt.Options[0].StringValue()
-		character := opt.Options[1].StringValue()
+	movieID := opt.Options[0].StringValue()
+	character := opt.Options[1].StringValue()
 
-		log := c.logger.WithGroup("cast").With("movieId", movieID, "character", character)
+	log := c.logger.WithGroup("cast").With("movieId", movieID, "character", character)
 
-		err := AddUserAsCastMember(context.TODO(), c.Storage(), movieID, intr.Member.User.ID, character)
-		if err != nil {
-			log.Error("failure adding cast member for movie", "err", err)
-			format.DisplayInteractionWithError(session, intr, "Error adding you as a cast member.", err)
-			return
-		}
+	err := AddUserAsCastMember(ctx, c.Storage(), intr.GuildID, movieID, intr.Member.User.ID, character)
+	if err != nil {
+		log.Error("failure adding cast member for movie", "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "movie.error_casting"), err)
+		return
+	}
+	if err := audit.Record(ctx, c.Storage(), intr, "movie cast", audit.Payload{"movieId": movieID, "character": character}); err != nil {
+		log.Error("failure recording audit entry", "err", err)
+	}
 
-		err = session.InteractionRespond(intr.Interaction,
-			&discordgo.InteractionResponse{
-				Type: discordgo.InteractionResponseChannelMessageWithSource,
-				Data: &discordgo.InteractionResponseData{
-					Content: "Movie cast member added!",
-					Flags:   discordgo.MessageFlagsEphemeral,
-				},
-			})
-		if err != nil {
-			log.Error("error responding to request", "err", err)
-		}
-	case discordgo.InteractionApplicationCommandAutocomplete:
-		if opt.Options[0].Focused {
-			err := c.moveListAutocomplete(session, intr)
-			if err != nil {
-				c.logger.Error("failure providing autocompletion for movie/cast/title", "err", err)
-			}
-		} else {
-			err := c.movieCastAutocomplete(session, intr)
-			if err != nil {
-				c.logger.Error("failure providing autocompletion for movie/cast/character", "err", err)
-			}
-		}
+	err = session.InteractionRespond(intr.Interaction,
+		&discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: i18n.T(intr, "movie.cast_added"),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+	if err != nil {
+		log.Error("error responding to request", "err", err)
 	}
 }
@@ -18,6 +18,7 @@ type Movie struct {
 	Image       string
 	AddedBy     string    `db:"addedBy"`
 	WatchedOn   time.Time `db:"watchedOn"`
+	GuildID     string    `db:"guildId"`
 
 	Ratings []MovieRating
 	Cast    []CastMember
@@ -27,8 +28,8 @@ func (m *Movie) GetURL() string {
 	return searchSource + "/title/" + m.ID
 }
 
-func doesMovieExist(tx *sqlighter.Tx, ID string) (bool, error) {
-	row := tx.QueryRowx("SELECT ID FROM Movies WHERE ID = ?", ID)
+func doesMovieExist(tx *sqlighter.Tx, guildID string, ID string) (bool, error) {
+	row := tx.QueryRowx("SELECT ID FROM Movies WHERE ID = ? AND guildId = ?", ID, guildID)
 
 	var id string
 	err := row.Scan(&id)
@@ -39,9 +40,9 @@ func doesMovieExist(tx *sqlighter.Tx, ID string) (bool, error) {
 	return true, err
 }
 
-func AddMovie(ctx context.Context, storage *database.Storage, ID string, user string, date time.Time) error {
+func AddMovie(ctx context.Context, storage *database.Storage, guildID string, ID string, user string, date time.Time) error {
 	return storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
-		movieExists, err := doesMovieExist(tx, ID)
+		movieExists, err := doesMovieExist(tx, guildID, ID)
 		if err != nil {
 			return err
 		}
@@ -54,7 +55,10 @@ func AddMovie(ctx context.Context, storage *database.Storage, ID string, user st
 			return err
 		}
 
-		_, err = tx.ExecContext(ctx, "INSERT INTO Movies VALUES(?, ?, ?, ?, ?, ?)", movie.ID, movie.Title, movie.Description, movie.Image, user, date.UTC())
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO Movies (id, guildId, title, description, image, addedBy, watchedOn) VALUES(?, ?, ?, ?, ?, ?, ?)",
+			movie.ID, guildID, movie.Title, movie.Description, movie.Image, user, date.UTC(),
+		)
 		if err != nil {
 			return fmt.Errorf("failure adding a movie: %w", err)
 		}
@@ -63,21 +67,21 @@ func AddMovie(ctx context.Context, storage *database.Storage, ID string, user st
 	})
 }
 
-func GetMovie(ctx context.Context, storage *database.Storage, ID string) (Movie, error) {
+func GetMovie(ctx context.Context, storage *database.Storage, guildID string, ID string) (Movie, error) {
 	res := Movie{}
 
 	return res, storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
-		err := tx.GetContext(ctx, &res, "SELECT * FROM Movies WHERE ID = ?", ID)
+		err := tx.GetContext(ctx, &res, "SELECT * FROM Movies WHERE ID = ? AND guildId = ?", ID, guildID)
 		if err != nil {
 			return fmt.Errorf("failure getting movies: %w", err)
 		}
 
-		err = tx.SelectContext(ctx, &res.Ratings, "SELECT * FROM MovieRatings WHERE movieId = ?", res.ID)
+		err = tx.SelectContext(ctx, &res.Ratings, "SELECT * FROM MovieRatings WHERE movieId = ? AND guildId = ?", res.ID, guildID)
 		if err != nil {
 			return fmt.Errorf("failure getting movie ratings: %w", err)
 		}
 
-		err = tx.SelectContext(ctx, &res.Cast, "SELECT * FROM MovieCast WHERE movieId = ?", res.ID)
+		err = tx.SelectContext(ctx, &res.Cast, "SELECT * FROM MovieCast WHERE movieId = ? AND guildId = ?", res.ID, guildID)
 		if err != nil {
 			return fmt.Errorf("failure getting movie cast: %w", err)
 		}
@@ -86,22 +90,22 @@ func GetMovie(ctx context.Context, storage *database.Storage, ID string) (Movie,
 	})
 }
 
-func GetMovies(ctx context.Context, storage *database.Storage) ([]Movie, error) {
+func GetMovies(ctx context.Context, storage *database.Storage, guildID string) ([]Movie, error) {
 	res := []Movie{}
 
 	return res, storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
-		err := tx.SelectContext(ctx, &res, "SELECT * FROM Movies ORDER BY watchedOn DESC")
+		err := tx.SelectContext(ctx, &res, "SELECT * FROM Movies WHERE guildId = ? ORDER BY watchedOn DESC", guildID)
 		if err != nil {
 			return fmt.Errorf("failure getting movies: %w", err)
 		}
 
 		for i, movie := range res {
-			err = tx.SelectContext(ctx, &res[i].Ratings, "SELECT * FROM MovieRatings WHERE movieId = ?", movie.ID)
+			err = tx.SelectContext(ctx, &res[i].Ratings, "SELECT * FROM MovieRatings WHERE movieId = ? AND guildId = ?", movie.ID, guildID)
 			if err != nil {
 				return fmt.Errorf("failure getting movie ratings: %w", err)
 			}
 
-			err = tx.SelectContext(ctx, &res[i].Cast, "SELECT * FROM MovieCast WHERE movieId = ?", movie.ID)
+			err = tx.SelectContext(ctx, &res[i].Cast, "SELECT * FROM MovieCast WHERE movieId = ? AND guildId = ?", movie.ID, guildID)
 			if err != nil {
 				return fmt.Errorf("failure getting movie cast: %w", err)
 			}
@@ -111,17 +115,17 @@ func GetMovies(ctx context.Context, storage *database.Storage) ([]Movie, error)
 	})
 }
 
-func GetMoviesByTitle(ctx context.Context, storage *database.Storage, title string) ([]Movie, error) {
+func GetMoviesByTitle(ctx context.Context, storage *database.Storage, guildID string, title string) ([]Movie, error) {
 	res := []Movie{}
 
 	return res, storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
-		err := tx.SelectContext(ctx, &res, "SELECT * FROM Movies WHERE title LIKE '%"+title+"%'")
+		err := tx.SelectContext(ctx, &res, "SELECT * FROM Movies WHERE guildId = ? AND title LIKE ?", guildID, "%"+title+"%")
 		if err != nil {
 			return fmt.Errorf("failure getting movies: %w", err)
 		}
 
 		for i, movie := range res {
-			err = tx.SelectContext(ctx, &res[i].Ratings, "SELECT * FROM MovieRatings WHERE movieId = ?", movie.ID)
+			err = tx.SelectContext(ctx, &res[i].Ratings, "SELECT * FROM MovieRatings WHERE movieId = ? AND guildId = ?", movie.ID, guildID)
 			if err != nil {
 				return fmt.Errorf("failure getting movie ratings: %w", err)
 			}
@@ -131,12 +135,12 @@ func GetMoviesByTitle(ctx context.Context, storage *database.Storage, title stri
 	})
 }
 
-func RateMovie(ctx context.Context, storage *database.Storage, ID string, user string, rating float64) error {
+func RateMovie(ctx context.Context, storage *database.Storage, guildID string, ID string, user string, rating float64) error {
 	return storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
 		_, err := tx.ExecContext(ctx,
-			`INSERT INTO MovieRatings VALUES(?, ?, ?)
-      ON CONFLICT(movieId, userId) DO UPDATE SET rating=excluded.rating`,
-			ID, user, rating,
+			`INSERT INTO MovieRatings (movieId, guildId, userId, rating) VALUES(?, ?, ?, ?)
+      ON CONFLICT(movieId, guildId, userId) DO UPDATE SET rating=excluded.rating`,
+			ID, guildID, user, rating,
 		)
 		if err != nil {
 			return fmt.Errorf("failure adding a rating: %w", err)
@@ -146,11 +150,11 @@ func RateMovie(ctx context.Context, storage *database.Storage, ID string, user s
 	})
 }
 
-func DeleteMovie(ctx context.Context, storage *database.Storage, ID string) error {
+func DeleteMovie(ctx context.Context, storage *database.Storage, guildID string, ID string) error {
 	return storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
 		_, err := tx.ExecContext(ctx,
-			`DELETE FROM Movies WHERE id = ?`,
-			ID,
+			`DELETE FROM Movies WHERE id = ? AND guildId = ?`,
+			ID, guildID,
 		)
 		return err
 	})
@@ -158,15 +162,16 @@ func DeleteMovie(ctx context.Context, storage *database.Storage, ID string) erro
 
 type MovieRating struct {
 	MovieID string `db:"movieId"`
+	GuildID string `db:"guildId"`
 	UserID  string `db:"userId"`
 	Rating  float64
 }
 
-func GetRatings(ctx context.Context, storage *database.Storage, movieID string) ([]MovieRating, error) {
+func GetRatings(ctx context.Context, storage *database.Storage, guildID string, movieID string) ([]MovieRating, error) {
 	res := []MovieRating{}
 
 	return res, storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
-		err := tx.SelectContext(ctx, &res, "SELECT * FROM MovieRatings WHERE movieId = ?", movieID)
+		err := tx.SelectContext(ctx, &res, "SELECT * FROM MovieRatings WHERE movieId = ? AND guildId = ?", movieID, guildID)
 		if err != nil {
 			return fmt.Errorf("failure getting movie ratings: %w", err)
 		}
@@ -177,15 +182,16 @@ func GetRatings(ctx context.Context, storage *database.Storage, movieID string)
 
 type CastMember struct {
 	MovieID   string `db:"movieId"`
+	GuildID   string `db:"guildId"`
 	UserID    string `db:"userId"`
 	Character string
 }
 
-func GetCast(ctx context.Context, storage *database.Storage, movieID string) ([]CastMember, error) {
+func GetCast(ctx context.Context, storage *database.Storage, guildID string, movieID string) ([]CastMember, error) {
 	res := []CastMember{}
 
 	return res, storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
-		err := tx.SelectContext(ctx, &res, "SELECT * FROM MovieCast WHERE movieId = ?", movieID)
+		err := tx.SelectContext(ctx, &res, "SELECT * FROM MovieCast WHERE movieId = ? AND guildId = ?", movieID, guildID)
 		if err != nil {
 			return fmt.Errorf("failure getting movie cast: %w", err)
 		}
@@ -194,12 +200,12 @@ func GetCast(ctx context.Context, storage *database.Storage, movieID string) ([]
 	})
 }
 
-func AddUserAsCastMember(ctx context.Context, storage *database.Storage, movieID string, userId string, character string) error {
+func AddUserAsCastMember(ctx context.Context, storage *database.Storage, guildID string, movieID string, userId string, character string) error {
 	return storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
 		_, err := tx.ExecContext(ctx,
-			`INSERT INTO MovieCast VALUES(?, ?, ?)
-      ON CONFLICT(movieId, userId) DO UPDATE SET character=excluded.character`,
-			movieID, userId, character)
+			`INSERT INTO MovieCast (movieId, guildId, userId, character) VALUES(?, ?, ?, ?)
+      ON CONFLICT(movieId, guildId, userId) DO UPDATE SET character=excluded.character`,
+			movieID, guildID, userId, character)
 		if err != nil {
 			return fmt.Errorf("failure adding a character: %w", err)
 		}
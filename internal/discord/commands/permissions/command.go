@@ -0,0 +1,109 @@
+package permissions
+
+import (
+	"log/slog"
+
+	"github.com/LeBulldoge/gungus/internal/database"
+	"github.com/LeBulldoge/gungus/internal/discord/bot"
+	"github.com/LeBulldoge/gungus/internal/discord/help"
+	"github.com/bwmarrin/discordgo"
+)
+
+type Command struct {
+	database.WithStorage
+
+	// paths of every enabled command and subcommand, e.g. "movie remove"
+	paths []string
+
+	logger *slog.Logger
+}
+
+func NewCommand() *Command {
+	return &Command{}
+}
+
+var manageGuild int64 = discordgo.PermissionManageGuild
+
+func (c *Command) GetSignature() []*discordgo.ApplicationCommand {
+	ruleOptions := []*discordgo.ApplicationCommandOption{
+		{
+			Name:         "command",
+			Description:  "Command or subcommand, e.g. movie remove",
+			Type:         discordgo.ApplicationCommandOptionString,
+			Required:     true,
+			Autocomplete: true,
+		},
+		{
+			Name:        "target",
+			Description: "Role or user",
+			Type:        discordgo.ApplicationCommandOptionMentionable,
+			Required:    true,
+		},
+	}
+
+	return []*discordgo.ApplicationCommand{
+		{
+			Name:                     "permissions",
+			Description:              "Manage who can use the commands of the bot",
+			DefaultMemberPermissions: &manageGuild,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Name:        "list",
+					Description: "Show the permission rules of this server",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+				},
+				{
+					Name:        "allow",
+					Description: "Allow a role or a user to use a command",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options:     ruleOptions,
+				},
+				{
+					Name:        "deny",
+					Description: "Deny a role or a user the use of a command",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options:     ruleOptions,
+				},
+				{
+					Name:        "reset",
+					Description: "Remove the rule of a role or a user for a command",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options:     ruleOptions,
+				},
+			},
+		},
+	}
+}
+
+func (c *Command) GetExamples() []help.Example {
+	return []help.Example{
+		{Command: "permissions deny", Usage: "/permissions deny command:movie remove target:@Guests", Description: "permissions.examples.deny"},
+		{Command: "permissions allow", Usage: "/permissions allow command:play target:@DJ", Description: "permissions.examples.allow"},
+	}
+}
+
+func (c *Command) AddLogger(logger *slog.Logger) {
+	if logger == nil {
+		return
+	}
+
+	c.logger = logger
+}
+
+func (c *Command) Setup(bot *bot.Bot) error {
+	c.paths = commandPaths(bot.Commands)
+
+	bot.Router.HandleCommand("permissions list", c.listRules)
+	bot.Router.HandleCommand("permissions allow", c.setRule)
+	bot.Router.HandleCommand("permissions deny", c.setRule)
+	bot.Router.HandleCommand("permissions reset", c.resetRule)
+	for _, sub := range []string{"allow", "deny", "reset"} {
+		bot.Router.HandleAutocomplete("permissions "+sub, "command", c.commandAutocomplete)
+	}
+
+	return nil
+}
+
+func (c *Command) Cleanup(bot *bot.Bot) error {
+	return nil
+}
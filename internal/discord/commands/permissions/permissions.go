@@ -0,0 +1,212 @@
+package permissions
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/LeBulldoge/gungus/internal/audit"
+	"github.com/LeBulldoge/gungus/internal/discord/embed"
+	"github.com/LeBulldoge/gungus/internal/discord/format"
+	"github.com/LeBulldoge/gungus/internal/discord/i18n"
+	"github.com/LeBulldoge/gungus/internal/permission"
+	"github.com/bwmarrin/discordgo"
+)
+
+// commandPaths lists the paths of the chat commands and their subcommands.
+func commandPaths(cmds []*discordgo.ApplicationCommand) []string {
+	var paths []string
+
+	var walk func(path string, opts []*discordgo.ApplicationCommandOption)
+	walk = func(path string, opts []*discordgo.ApplicationCommandOption) {
+		paths = append(paths, path)
+		for _, opt := range opts {
+			if opt.Type == discordgo.ApplicationCommandOptionSubCommand || opt.Type == discordgo.ApplicationCommandOptionSubCommandGroup {
+				walk(path+" "+opt.Name, opt.Options)
+			}
+		}
+	}
+
+	for _, cmd := range cmds {
+		walk(cmd.Name, cmd.Options)
+	}
+	slices.Sort(paths)
+
+	return paths
+}
+
+func (c *Command) listRules(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	rules, err := permission.GetRules(ctx, c.Storage(), intr.GuildID)
+	if err != nil {
+		c.logger.Error("failure getting rules", "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "permissions.error_getting"), err)
+		return
+	}
+
+	var sb strings.Builder
+	for _, r := range rules {
+		key := "permissions.denied"
+		if r.Allow {
+			key = "permissions.allowed"
+		}
+		sb.WriteString(i18n.T(intr, key, r.Command, r.Mention()))
+		sb.WriteRune('\n')
+	}
+	if len(rules) == 0 {
+		sb.WriteString(i18n.T(intr, "permissions.empty"))
+	}
+
+	builder := embed.NewEmbed().
+		SetTitle(i18n.T(intr, "permissions.title")).
+		SetDescription(sb.String()).
+		SetFooter(i18n.T(intr, "permissions.footer"), "")
+
+	err = session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:          []*discordgo.MessageEmbed{builder.MessageEmbed},
+			Flags:           discordgo.MessageFlagsEphemeral,
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		},
+	})
+	if err != nil {
+		c.logger.Error("failure responding to interaction", "err", err)
+	}
+}
+
+// ruleOf builds the rule the options of a subcommand describe.
+func (c *Command) ruleOf(intr *discordgo.InteractionCreate) (permission.Rule, error) {
+	data := intr.ApplicationCommandData()
+	opt := data.Options[0]
+
+	rule := permission.Rule{
+		GuildID:  intr.GuildID,
+		Command:  strings.TrimPrefix(opt.Options[0].StringValue(), "/"),
+		TargetID: opt.Options[1].Value.(string),
+		Allow:    opt.Name == "allow",
+	}
+
+	if !slices.Contains(c.paths, rule.Command) {
+		return rule, errors.New(i18n.T(intr, "permissions.unknown_command", rule.Command))
+	}
+
+	rule.TargetType = permission.TargetUser
+	if data.Resolved != nil {
+		if _, ok := data.Resolved.Roles[rule.TargetID]; ok {
+			rule.TargetType = permission.TargetRole
+		}
+	}
+	if rule.TargetID == intr.GuildID {
+		rule.TargetType = permission.TargetRole
+	}
+
+	return rule, nil
+}
+
+func (c *Command) setRule(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	if !permission.CanManage(ctx, intr.Member) {
+		format.DisplayInteractionError(session, intr, i18n.T(intr, "permissions.manage_only"))
+		return
+	}
+
+	rule, err := c.ruleOf(intr)
+	if err != nil {
+		format.DisplayInteractionError(session, intr, err.Error())
+		return
+	}
+
+	log := c.logger.With("command", rule.Command, "target", rule.TargetID, "allow", rule.Allow)
+
+	err = permission.SetRule(ctx, c.Storage(), rule)
+	if err != nil {
+		log.Error("failure saving rule", "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "permissions.error_saving"), err)
+		return
+	}
+	payload := audit.Payload{"command": rule.Command, "targetId": rule.TargetID, "targetType": rule.TargetType, "allow": rule.Allow}
+	if err := audit.Record(ctx, c.Storage(), intr, "permissions "+intr.ApplicationCommandData().Options[0].Name, payload); err != nil {
+		log.Error("failure recording audit entry", "err", err)
+	}
+
+	key := "permissions.now_denied"
+	if rule.Allow {
+		key = "permissions.now_allowed"
+	}
+	c.respond(session, intr, i18n.T(intr, key, rule.Command, rule.Mention()))
+
+	log.Info("rule saved")
+}
+
+func (c *Command) resetRule(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	if !permission.CanManage(ctx, intr.Member) {
+		format.DisplayInteractionError(session, intr, i18n.T(intr, "permissions.manage_only"))
+		return
+	}
+
+	rule, err := c.ruleOf(intr)
+	if err != nil {
+		format.DisplayInteractionError(session, intr, err.Error())
+		return
+	}
+
+	deleted, err := permission.DeleteRule(ctx, c.Storage(), rule.GuildID, rule.Command, rule.TargetID)
+	if err != nil {
+		c.logger.Error("failure deleting rule", "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "permissions.error_deleting"), err)
+		return
+	}
+	if !deleted {
+		format.DisplayInteractionError(session, intr, i18n.T(intr, "permissions.no_rule", rule.Mention(), rule.Command))
+		return
+	}
+	payload := audit.Payload{"command": rule.Command, "targetId": rule.TargetID, "targetType": rule.TargetType}
+	if err := audit.Record(ctx, c.Storage(), intr, "permissions reset", payload); err != nil {
+		c.logger.Error("failure recording audit entry", "err", err)
+	}
+
+	c.respond(session, intr, i18n.T(intr, "permissions.removed", rule.Mention(), rule.Command))
+}
+
+func (c *Command) respond(session *discordgo.Session, intr *discordgo.InteractionCreate, content string) {
+	err := session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content:         content,
+			Flags:           discordgo.MessageFlagsEphemeral,
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		},
+	})
+	if err != nil {
+		c.logger.Error("failure responding to interaction", "err", err)
+	}
+}
+
+func (c *Command) commandAutocomplete(_ context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	var query string
+	for _, opt := range intr.ApplicationCommandData().Options[0].Options {
+		if opt.Focused {
+			query = strings.ToLower(strings.TrimPrefix(opt.StringValue(), "/"))
+		}
+	}
+
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	for _, path := range c.paths {
+		if len(choices) == 25 {
+			break
+		}
+		if strings.Contains(path, query) {
+			choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: "/" + path, Value: path})
+		}
+	}
+
+	err := session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+	if err != nil {
+		c.logger.Error("failure responding to autocomplete", "err", err)
+	}
+}
@@ -3,18 +3,33 @@ package play
 import (
 	"context"
 	"log/slog"
+	"sync"
 
+	"github.com/LeBulldoge/gungus/internal/config"
 	"github.com/LeBulldoge/gungus/internal/database"
 	"github.com/LeBulldoge/gungus/internal/discord/bot"
 	"github.com/LeBulldoge/gungus/internal/discord/commands/play/playback"
+	"github.com/LeBulldoge/gungus/internal/discord/help"
+	"github.com/LeBulldoge/gungus/internal/discord/ops"
 	"github.com/bwmarrin/discordgo"
 )
 
 type Command struct {
+	database.WithStorage
+
 	playerStorage playback.PlayerStorage
 
 	autocompleteCancelMap map[string]context.CancelFunc
 
+	playlistMu       sync.Mutex
+	playlistConfirms map[string]playlistConfirmation
+
+	// ctx is the bot context, players stop when it is cancelled
+	ctx     context.Context
+	players sync.WaitGroup
+
+	ops    *ops.Reporter
+	config config.Play
 	logger *slog.Logger
 }
 
@@ -22,6 +37,7 @@ func NewCommand() *Command {
 	return &Command{
 		playerStorage:         playback.NewManager(),
 		autocompleteCancelMap: map[string]context.CancelFunc{},
+		playlistConfirms:      map[string]playlistConfirmation{},
 	}
 }
 
@@ -88,28 +104,34 @@ func (c *Command) GetSignature() []*discordgo.ApplicationCommand {
 }
 
 func (c *Command) Setup(bot *bot.Bot) error {
-	bot.Session.AddHandler(func(sesh *discordgo.Session, intr *discordgo.InteractionCreate) {
-		if intr.Type != discordgo.InteractionApplicationCommand && intr.Type != discordgo.InteractionApplicationCommandAutocomplete {
-			return
-		}
-		opt := intr.ApplicationCommandData()
-		switch opt.Name {
-		case "play":
-			c.handlePlay(sesh, intr)
-		case "skip":
-			c.handleSkip(sesh, intr)
-		case "queue":
-			c.HandleQueue(sesh, intr)
-		}
-	})
+	c.ctx = bot.Context()
+	c.ops = bot.Ops
+	c.config = bot.Config.Play
+
+	bot.Router.HandleCommand("play", c.handlePlay)
+	bot.Router.HandleAutocomplete("play", "search", c.handlePlayAutocomplete)
+	bot.Router.WithCommand("play").HandleComponent(playlistNamespace, c.handlePlaylistConfirm)
+	bot.Router.HandleCommand("stop", c.handleStop)
+	bot.Router.HandleCommand("skip", c.handleSkip)
+	bot.Router.HandleCommand("queue", c.HandleQueue)
 
 	return nil
 }
 
+// Cleanup waits for the players, stopped by the cancelled bot context,
+// to announce it and leave their voice channels.
 func (c *Command) Cleanup(bot *bot.Bot) error {
+	c.players.Wait()
 	return nil
 }
 
+func (c *Command) GetExamples() []help.Example {
+	return []help.Example{
+		{Command: "play", Usage: "/play search:https://youtu.be/dQw4w9WgXcQ", Description: "play.examples.link"},
+		{Command: "play", Usage: "/play search:lofi hip hop at:1", Description: "play.examples.search"},
+	}
+}
+
 func (c *Command) AddLogger(logger *slog.Logger) {
 	if logger == nil {
 		return
@@ -118,4 +140,7 @@ func (c *Command) AddLogger(logger *slog.Logger) {
 	c.logger = logger
 }
 
-func (c *Command) SetStorageConnection(*database.Storage) {}
+// ActivePlayers returns the number of guilds audio is being played in.
+func (c *Command) ActivePlayers() int {
+	return c.playerStorage.Count()
+}
@@ -6,13 +6,18 @@ import (
 	"fmt"
 	"log/slog"
 	"net/url"
-	"strings"
 	"sync"
 	"time"
 
+	"github.com/LeBulldoge/gungus/internal/audit"
+	"github.com/LeBulldoge/gungus/internal/discord/bot"
 	"github.com/LeBulldoge/gungus/internal/discord/commands/play/playback"
 	"github.com/LeBulldoge/gungus/internal/discord/embed"
 	"github.com/LeBulldoge/gungus/internal/discord/format"
+	"github.com/LeBulldoge/gungus/internal/discord/i18n"
+	"github.com/LeBulldoge/gungus/internal/discord/ops"
+	"github.com/LeBulldoge/gungus/internal/discord/router"
+	"github.com/LeBulldoge/gungus/internal/settings"
 	"github.com/LeBulldoge/gungus/internal/youtube"
 	"github.com/bwmarrin/discordgo"
 )
@@ -26,7 +31,7 @@ func autocompleteResponse(choices []*discordgo.ApplicationCommandOptionChoice) *
 	}
 }
 
-func (c *Command) handlePlayAutocomplete(session *discordgo.Session, intr *discordgo.InteractionCreate) {
+func (c *Command) handlePlayAutocomplete(_ context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
 	opt := intr.ApplicationCommandData()
 	queryString := opt.Options[0].StringValue()
 	log := c.logger.With(slog.Group("play/autocomplete", "query", queryString))
@@ -100,11 +105,7 @@ func isHostAllowed(host string) bool {
 	return false
 }
 
-func (c *Command) handlePlay(session *discordgo.Session, intr *discordgo.InteractionCreate) {
-	if intr.Type == discordgo.InteractionApplicationCommandAutocomplete {
-		c.handlePlayAutocomplete(session, intr)
-		return
-	}
+func (c *Command) handlePlay(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
 	opt := intr.ApplicationCommandData()
 	queryString := opt.Options[0].StringValue()
 	index := -1
@@ -118,12 +119,12 @@ func (c *Command) handlePlay(session *discordgo.Session, intr *discordgo.Interac
 	url, err := url.ParseRequestURI(queryString)
 	if err != nil {
 		log.Error("error parsing url", "err", err)
-		format.DisplayInteractionError(session, intr, "Error parsing url!")
+		format.DisplayInteractionError(session, intr, i18n.T(intr, "play.invalid_url"))
 		return
 	}
 	if !isHostAllowed(url.Host) {
 		log.Error("error parsing url: incorrect domain")
-		format.DisplayInteractionError(session, intr, "Domain must be `youtube.com`, `youtu.be` and etc.")
+		format.DisplayInteractionError(session, intr, i18n.T(intr, "play.invalid_domain"))
 		return
 	}
 
@@ -142,15 +143,19 @@ func (c *Command) handlePlay(session *discordgo.Session, intr *discordgo.Interac
 	if url.Query().Has("list") && !url.Query().Has("watch") {
 		log.Info("requesting confirmation for playlist")
 
-		confirmChan := make(chan bool)
+		confirmChan := make(chan bool, 1)
 		cancel, err := c.handlePlaylist(session, intr, confirmChan)
 		if err != nil {
 			log.Error("error while handling playlist", "err", err)
 			return
 		}
 
-		timeoutChan := time.After(time.Minute)
+		timeoutChan := time.After(c.config.PlaylistTimeout)
 		select {
+		case <-ctx.Done():
+			log.Info("playlist confirmation cancelled", "cause", context.Cause(ctx))
+			cancel()
+			return
 		case result := <-confirmChan:
 			if !result {
 				log.Info("declined adding playlist")
@@ -165,8 +170,8 @@ func (c *Command) handlePlay(session *discordgo.Session, intr *discordgo.Interac
 				Components: &[]discordgo.MessageComponent{
 					discordgo.Container{
 						Components: []discordgo.MessageComponent{
-							discordgo.TextDisplay{Content: "## Playlist confirmation"},
-							discordgo.TextDisplay{Content: "Confirmation for adding a playlist timed out"},
+							discordgo.TextDisplay{Content: i18n.T(intr, "play.playlist_title")},
+							discordgo.TextDisplay{Content: i18n.T(intr, "play.playlist_timeout")},
 						},
 					},
 				},
@@ -180,13 +185,13 @@ func (c *Command) handlePlay(session *discordgo.Session, intr *discordgo.Interac
 
 	log.Info("requesting video data", "url", videoURL)
 
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
 	ytDataChan := make(chan youtube.SearchResult, 50)
 	if err := youtube.GetYoutubeData(ctx, videoURL, ytDataChan); err != nil {
 		log.Error("error getting youtube data", "err", err)
-		format.DisplayInteractionError(session, intr, "Error getting video data from youtube. See the log for details.")
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "play.error_video_data"), err)
 		return
 	}
 
@@ -195,7 +200,7 @@ func (c *Command) handlePlay(session *discordgo.Session, intr *discordgo.Interac
 		case errors.Is(err, errUserNotInAnyChannel):
 			fallthrough
 		case errors.Is(err, errUserNotInBotsChannel):
-			format.DisplayInteractionError(session, intr, "You must be in the same voice channel as the bot to use this command.")
+			format.DisplayInteractionError(session, intr, i18n.T(intr, "play.not_in_bot_channel"))
 			return
 		}
 	}
@@ -210,7 +215,7 @@ func (c *Command) handlePlay(session *discordgo.Session, intr *discordgo.Interac
 		channelID, err := c.getUserChannelID(session, intr.GuildID, intr.Member.User.ID)
 		if err != nil {
 			log.Error("failure getting channel id", "err", err)
-			format.DisplayInteractionError(session, intr, "You must be in a voice channel to use this command.")
+			format.DisplayInteractionError(session, intr, i18n.T(intr, "play.not_in_channel"))
 			return
 		}
 
@@ -220,18 +225,18 @@ func (c *Command) handlePlay(session *discordgo.Session, intr *discordgo.Interac
 				voice.Close()
 			}
 			log.Error("failure joining voice channel", "channelId", channelID, "err", err)
-			format.DisplayInteractionError(session, intr, "Error joining voice channel.")
+			format.DisplayInteractionWithError(session, intr, i18n.T(intr, "play.error_joining"), err)
 			return
 		}
 
 		var wg sync.WaitGroup
 		wg.Add(1)
-		player = c.setupPlayer(session, intr, voice, log, &wg)
-		if player == nil {
+		player, err = c.setupPlayer(ctx, session, intr, voice, log, &wg)
+		if err != nil {
 			if voice != nil {
 				voice.Close()
 			}
-			format.DisplayInteractionError(session, intr, "Error starting playback.")
+			format.DisplayInteractionWithError(session, intr, i18n.T(intr, "play.error_starting"), err)
 			return
 		}
 		wg.Wait()
@@ -240,7 +245,7 @@ func (c *Command) handlePlay(session *discordgo.Session, intr *discordgo.Interac
 	for ytData := range ytDataChan {
 		if ytData.Error != nil {
 			log.Error("failure getting url from GetYoutubeData", "err", ytData.Error)
-			format.DisplayInteractionError(session, intr, "Error getting song data.")
+			format.DisplayInteractionWithError(session, intr, i18n.T(intr, "play.error_song_data"), ytData.Error)
 			continue
 		}
 		video := ytData.Video
@@ -259,13 +264,16 @@ func (c *Command) handlePlay(session *discordgo.Session, intr *discordgo.Interac
 		}
 
 		log.Info("added video to player", "video", video.Title)
+		if err := audit.Record(ctx, c.Storage(), intr, "play", audit.Payload{"url": video.GetShortURL(), "title": video.Title}); err != nil {
+			log.Error("failure recording audit entry", "err", err)
+		}
 
 		embed := embed.NewEmbed().
-			SetAuthor("Added to queue").
+			SetAuthor(i18n.T(intr, "play.added_to_queue")).
 			SetTitle(video.Title).
 			SetUrl(video.GetShortURL()).
 			SetDescription(video.Length).
-			SetFooter(fmt.Sprintf("Queue length: %d", player.Count()), "").
+			SetFooter(i18n.T(intr, "play.queue_length", player.Count()), "").
 			MessageEmbed
 
 		_, err = session.FollowupMessageCreate(intr.Interaction, false, &discordgo.WebhookParams{
@@ -278,24 +286,31 @@ func (c *Command) handlePlay(session *discordgo.Session, intr *discordgo.Interac
 	}
 }
 
+const playlistNamespace = "playlist"
+
+type playlistConfirmation struct {
+	intr    *discordgo.InteractionCreate
+	confirm chan<- bool
+}
+
 func (c *Command) handlePlaylist(session *discordgo.Session, intr *discordgo.InteractionCreate, confirm chan bool) (func(), error) {
 	_, err := session.FollowupMessageCreate(intr.Interaction, true, &discordgo.WebhookParams{
 		Flags: discordgo.MessageFlagsEphemeral | discordgo.MessageFlagsIsComponentsV2,
 		Components: []discordgo.MessageComponent{
 			discordgo.Container{
 				Components: []discordgo.MessageComponent{
-					discordgo.TextDisplay{Content: "## Playlist confirmation"},
-					discordgo.TextDisplay{Content: "You are about to add a playlist. Are you sure?"},
+					discordgo.TextDisplay{Content: i18n.T(intr, "play.playlist_title")},
+					discordgo.TextDisplay{Content: i18n.T(intr, "play.playlist_confirm")},
 					discordgo.ActionsRow{
 						Components: []discordgo.MessageComponent{
 							discordgo.Button{
-								Label:    "Yes",
-								CustomID: "playlistConfirm_Yes",
+								Label:    i18n.T(intr, "play.yes"),
+								CustomID: router.NewCustomID(playlistNamespace, intr.ID, "yes").String(),
 								Style:    discordgo.PrimaryButton,
 							},
 							discordgo.Button{
-								Label:    "No",
-								CustomID: "playlistConfirm_No",
+								Label:    i18n.T(intr, "play.no"),
+								CustomID: router.NewCustomID(playlistNamespace, intr.ID, "no").String(),
 								Style:    discordgo.DangerButton,
 							},
 						},
@@ -308,53 +323,80 @@ func (c *Command) handlePlaylist(session *discordgo.Session, intr *discordgo.Int
 		return nil, fmt.Errorf("failure creating followup message to interaction: %w", err)
 	}
 
-	cancel := session.AddHandler(func(sesh *discordgo.Session, buttonIntr *discordgo.InteractionCreate) {
-		switch buttonIntr.Type {
-		case discordgo.InteractionMessageComponent:
-			customID := buttonIntr.MessageComponentData().CustomID
-			if !strings.HasPrefix(customID, "playlistConfirm") {
-				return
-			}
+	c.playlistMu.Lock()
+	c.playlistConfirms[intr.ID] = playlistConfirmation{intr: intr, confirm: confirm}
+	c.playlistMu.Unlock()
 
-			confirmed := strings.Split(customID, "_")[1] == "Yes"
+	cancel := func() {
+		c.playlistMu.Lock()
+		delete(c.playlistConfirms, intr.ID)
+		c.playlistMu.Unlock()
+	}
 
-			if confirmed {
-				session.InteractionResponseEdit(intr.Interaction, &discordgo.WebhookEdit{
-					Components: &[]discordgo.MessageComponent{
-						discordgo.Container{
-							Components: []discordgo.MessageComponent{
-								discordgo.TextDisplay{Content: "## Playlist confirmation"},
-								discordgo.TextDisplay{Content: "Adding playlist..."},
-							},
-						},
-					},
-				})
-			} else {
-				session.InteractionResponseDelete(intr.Interaction)
-			}
+	return cancel, nil
+}
 
-			confirm <- confirmed
-		}
+func (c *Command) handlePlaylistConfirm(_ context.Context, session *discordgo.Session, buttonIntr *discordgo.InteractionCreate) {
+	customID := router.ParseCustomID(buttonIntr.MessageComponentData().CustomID)
+
+	c.playlistMu.Lock()
+	pending, ok := c.playlistConfirms[customID.Arg(0)]
+	delete(c.playlistConfirms, customID.Arg(0))
+	c.playlistMu.Unlock()
+	if !ok {
+		format.DisplayInteractionError(session, buttonIntr, i18n.T(buttonIntr, "play.confirmation_expired"))
+		return
+	}
+
+	err := session.InteractionRespond(buttonIntr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredMessageUpdate,
 	})
+	if err != nil {
+		c.logger.Error("error creating deferred response", "err", err)
+	}
 
-	return cancel, nil
+	intr := pending.intr
+	confirmed := customID.Arg(1) == "yes"
+
+	if confirmed {
+		session.InteractionResponseEdit(intr.Interaction, &discordgo.WebhookEdit{
+			Components: &[]discordgo.MessageComponent{
+				discordgo.Container{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextDisplay{Content: i18n.T(intr, "play.playlist_title")},
+						discordgo.TextDisplay{Content: i18n.T(intr, "play.playlist_adding")},
+					},
+				},
+			},
+		})
+	} else {
+		session.InteractionResponseDelete(intr.Interaction)
+	}
+
+	pending.confirm <- confirmed
 }
 
-func (c *Command) setupPlayer(session *discordgo.Session, intr *discordgo.InteractionCreate, voice *discordgo.VoiceConnection, log *slog.Logger, wg *sync.WaitGroup) *playback.Player {
-	player := playback.NewPlayer(voice)
+func (c *Command) setupPlayer(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate, voice *discordgo.VoiceConnection, log *slog.Logger, wg *sync.WaitGroup) (*playback.Player, error) {
+	player := playback.NewPlayer(voice, playback.Options{
+		Bitrate: c.config.Bitrate,
+		Volume:  settings.Volume.Get(ctx, c.Storage(), intr.GuildID),
+	})
+	idleTimeout := settings.IdleTimeout.Get(ctx, c.Storage(), intr.GuildID)
 	if err := c.playerStorage.Add(intr.GuildID, player); err != nil {
 		log.Error("error adding a new playback service", "guildId", intr.GuildID, "err", err)
-		return nil
+		return nil, fmt.Errorf("failure adding a player: %w", err)
 	}
 
 	// Run the service
-	go func(guildId string) {
-		playbackContext, playbackCancel := context.WithCancelCause(context.Background())
-		stopHandlerCancel := createStopHandler(session, playbackCancel, guildId)
+	c.players.Add(1)
+	go func(guildId string, textChannelID string) {
+		defer c.players.Done()
+
+		playbackContext, playbackCancel := context.WithCancelCause(c.ctx)
 
 		// Setup service timeout ticker, in case bot is left alone in a channel
 		go func(channelId string) {
-			tick := time.NewTicker(time.Minute)
+			tick := time.NewTicker(idleTimeout)
 			defer tick.Stop()
 			for {
 				select {
@@ -373,11 +415,22 @@ func (c *Command) setupPlayer(session *discordgo.Session, intr *discordgo.Intera
 		}(player.ChannelID())
 
 		err := player.Run(playbackContext, wg)
-		if err != nil {
+		shutdown := errors.Is(context.Cause(playbackContext), bot.ErrShutdown)
+		if err != nil && !shutdown {
 			log.Error("playback error has occured", "err", err)
 		}
+		if err != nil && !shutdown && !errors.Is(err, playback.ErrCauseStop) && !errors.Is(err, playback.ErrCauseTimeout) {
+			c.reportPlaybackFailure(session, intr, textChannelID, err, log)
+		}
 
-		stopHandlerCancel()
+		playbackCancel(nil)
+
+		if shutdown {
+			_, err := session.ChannelMessageSend(textChannelID, i18n.TGuild(intr, "play.stopping_shutdown"))
+			if err != nil {
+				log.Error("failure announcing shutdown", "err", err)
+			}
+		}
 
 		if err := player.Cleanup(); err != nil {
 			log.Error("failure to close player", "err", err)
@@ -387,37 +440,57 @@ func (c *Command) setupPlayer(session *discordgo.Session, intr *discordgo.Intera
 		if err := c.playerStorage.Delete(guildId); err != nil {
 			log.Error("error deleting player", "guildId", guildId, "err", err)
 		}
-	}(intr.GuildID)
+	}(intr.GuildID, intr.ChannelID)
 
-	return player
+	return player, nil
 }
 
-func createStopHandler(sesh *discordgo.Session, cancel context.CancelCauseFunc, guildID string) func() {
-	return sesh.AddHandler(func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-		if i.GuildID != guildID {
-			return
-		}
+// reportPlaybackFailure posts err to the ops channels and tells the listeners
+// playback stopped, with a reference to the report.
+func (c *Command) reportPlaybackFailure(session *discordgo.Session, intr *discordgo.InteractionCreate, textChannelID string, err error, log *slog.Logger) {
+	id := ops.NewCorrelationID()
+	log.Info("reporting playback failure", "id", id)
+
+	c.ops.Report(ops.Report{
+		Title:   "Playback failed",
+		ID:      id,
+		GuildID: intr.GuildID,
+		Command: "play",
+		UserID:  intr.Member.User.ID,
+		Err:     err,
+	})
 
-		opt := i.ApplicationCommandData()
-		if opt.Name != "stop" {
-			return
-		}
+	_, err = session.ChannelMessageSend(textChannelID, i18n.TGuild(intr, "play.stopped_error")+"\n-# "+i18n.TGuild(intr, "errors.reference", id))
+	if err != nil {
+		log.Error("failure announcing playback failure", "err", err)
+	}
+}
 
-		err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: "Stopping playback.",
-			},
-		})
-		if err != nil {
-			format.DisplayInteractionError(s, i, "Failure responding to interaction. See the log for details.")
-		}
+func (c *Command) handleStop(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) {
+	player := c.playerStorage.Get(i.GuildID)
+	if player == nil {
+		format.DisplayInteractionError(s, i, i18n.T(i, "play.nothing_to_stop"))
+		return
+	}
 
-		cancel(playback.ErrCauseStop)
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: i18n.T(i, "play.stopping"),
+		},
 	})
+	if err != nil {
+		format.DisplayInteractionError(s, i, i18n.T(i, "errors.responding"))
+	}
+
+	player.Stop(playback.ErrCauseStop)
+
+	if err := audit.Record(ctx, c.Storage(), i, "stop", audit.Payload{}); err != nil {
+		c.logger.Error("failure recording audit entry", "err", err)
+	}
 }
 
-func (c *Command) handleSkip(sesh *discordgo.Session, intr *discordgo.InteractionCreate) {
+func (c *Command) handleSkip(ctx context.Context, sesh *discordgo.Session, intr *discordgo.InteractionCreate) {
 	guildID := intr.GuildID
 	userID := intr.Member.User.ID
 
@@ -426,10 +499,10 @@ func (c *Command) handleSkip(sesh *discordgo.Session, intr *discordgo.Interactio
 		case errors.Is(err, errUserNotInAnyChannel):
 			fallthrough
 		case errors.Is(err, errUserNotInBotsChannel):
-			format.DisplayInteractionError(sesh, intr, "You must be in the same voice channel as the bot to use this command.")
+			format.DisplayInteractionError(sesh, intr, i18n.T(intr, "play.not_in_bot_channel"))
 			return
 		case errors.Is(err, errBotIsNotInAnyChannel):
-			format.DisplayInteractionError(sesh, intr, "Nothing to skip.")
+			format.DisplayInteractionError(sesh, intr, i18n.T(intr, "play.nothing_to_skip"))
 			return
 		}
 	}
@@ -444,23 +517,26 @@ func (c *Command) handleSkip(sesh *discordgo.Session, intr *discordgo.Interactio
 	if ps := c.playerStorage.Get(guildID); ps != nil {
 		err := ps.Skip(int(skipAmount))
 		if errors.Is(err, playback.ErrSkipUnavailable) {
-			format.DisplayInteractionError(sesh, intr, "Nothing to skip yet.")
+			format.DisplayInteractionError(sesh, intr, i18n.T(intr, "play.nothing_to_skip_yet"))
 			return
 		}
 	} else {
-		format.DisplayInteractionError(sesh, intr, "Nothing to skip.")
+		format.DisplayInteractionError(sesh, intr, i18n.T(intr, "play.nothing_to_skip"))
 		return
 	}
+	if err := audit.Record(ctx, c.Storage(), intr, "skip", audit.Payload{"amount": skipAmount}); err != nil {
+		c.logger.Error("failure recording audit entry", "err", err)
+	}
 
 	err := sesh.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Content: "Skipped current song.",
+			Content: i18n.T(intr, "play.skipped"),
 		},
 	})
 	if err != nil {
 		slog.Error("failure responding to interaction", "err", err)
-		format.DisplayInteractionError(sesh, intr, "Failure responding to interaction. See the log for details.")
+		format.DisplayInteractionError(sesh, intr, i18n.T(intr, "errors.responding"))
 	}
 }
 
@@ -3,6 +3,8 @@ package playback
 import (
 	"errors"
 	"sync"
+
+	"github.com/LeBulldoge/gungus/internal/metrics"
 )
 
 type PlayerStorage struct {
@@ -29,6 +31,13 @@ func (m *PlayerStorage) Get(guildID string) *Player {
 	return res
 }
 
+func (m *PlayerStorage) Count() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return len(m.services)
+}
+
 func (m *PlayerStorage) Add(guildID string, ps *Player) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -38,6 +47,7 @@ func (m *PlayerStorage) Add(guildID string, ps *Player) error {
 	}
 
 	m.services[guildID] = ps
+	metrics.ActivePlayers.WithLabelValues(guildID).Set(1)
 
 	return nil
 }
@@ -51,6 +61,8 @@ func (m *PlayerStorage) Delete(guildID string) error {
 	}
 
 	delete(m.services, guildID)
+	metrics.ActivePlayers.DeleteLabelValues(guildID)
+	metrics.QueueLength.DeleteLabelValues(guildID)
 
 	return nil
 }
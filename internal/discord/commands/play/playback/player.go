@@ -3,15 +3,20 @@ package playback
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"iter"
 	"log/slog"
+	stdos "os"
 	"os/exec"
+	"path/filepath"
 	"slices"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/ClintonCollins/dca"
+	"github.com/LeBulldoge/gungus/internal/metrics"
 	"github.com/LeBulldoge/gungus/internal/os"
 	"github.com/LeBulldoge/gungus/internal/youtube"
 	"github.com/bwmarrin/discordgo"
@@ -24,23 +29,67 @@ var (
 	ErrSkipUnavailable = errors.New("queue is empty")
 )
 
+// SetFFmpegPath makes the ffmpeg executable at path available to the encoder.
+// dca always runs "ffmpeg" from PATH, so the directory of path is prepended to it.
+func SetFFmpegPath(path string) error {
+	if path == "ffmpeg" {
+		return nil
+	}
+
+	if filepath.Base(path) != "ffmpeg" {
+		return fmt.Errorf("ffmpeg executable must be named ffmpeg, got %s", path)
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	return stdos.Setenv("PATH", filepath.Dir(abs)+string(filepath.ListSeparator)+stdos.Getenv("PATH"))
+}
+
+// FFmpegVersion returns the version reported by the ffmpeg executable.
+func FFmpegVersion(ctx context.Context) (string, error) {
+	out, err := exec.CommandContext(ctx, "ffmpeg", "-version").Output()
+	if err != nil {
+		return "", err
+	}
+
+	// ffmpeg version 6.1-static https://johnvansickle.com/ffmpeg/ Copyright ...
+	fields := strings.Fields(string(out))
+	if len(fields) < 3 || fields[1] != "version" {
+		return "", fmt.Errorf("unexpected ffmpeg version output: %q", out)
+	}
+
+	return fields[2], nil
+}
+
+type Options struct {
+	Bitrate int
+	// Volume in percent
+	Volume int
+}
+
 type Player struct {
 	mu sync.RWMutex
 
 	vc      *discordgo.VoiceConnection
 	running bool
+	options Options
 
 	skipFunc context.CancelCauseFunc
+	stopFunc context.CancelCauseFunc
 
 	queue []youtube.Video
 
 	logger *slog.Logger
 }
 
-func NewPlayer(vc *discordgo.VoiceConnection) *Player {
+func NewPlayer(vc *discordgo.VoiceConnection, options Options) *Player {
 	return &Player{
-		vc:    vc,
-		queue: make([]youtube.Video, 0),
+		vc:      vc,
+		options: options,
+		queue:   make([]youtube.Video, 0),
 		logger: slog.Default().
 			WithGroup("player").
 			With("guildID", vc.GuildID, "channelID", vc.ChannelID),
@@ -55,6 +104,7 @@ func (s *Player) Add(video youtube.Video) error {
 	}
 
 	s.queue = append(s.queue, video)
+	s.updateQueueLength()
 
 	return nil
 }
@@ -71,6 +121,7 @@ func (s *Player) Insert(video youtube.Video, index int) error {
 	}
 
 	s.queue = slices.Insert(s.queue, index, video)
+	s.updateQueueLength()
 
 	return nil
 }
@@ -81,6 +132,7 @@ func (s *Player) getNextVideo() iter.Seq[youtube.Video] {
 			s.mu.Lock()
 			video := s.queue[0]
 			s.queue = s.queue[1:]
+			s.updateQueueLength()
 			s.mu.Unlock()
 			if !yield(video) {
 				return
@@ -89,6 +141,11 @@ func (s *Player) getNextVideo() iter.Seq[youtube.Video] {
 	}
 }
 
+// updateQueueLength must be called with s.mu held.
+func (s *Player) updateQueueLength() {
+	metrics.QueueLength.WithLabelValues(s.vc.GuildID).Set(float64(len(s.queue)))
+}
+
 func (s *Player) waitForVideos(ctx context.Context) {
 	for {
 		if s.Count() > 0 {
@@ -117,6 +174,15 @@ func (s *Player) Skip(cnt int) error {
 	return nil
 }
 
+// Stop ends playback with cause.
+func (s *Player) Stop(cause error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopFunc != nil {
+		s.stopFunc(cause)
+	}
+}
+
 func (s *Player) Queue() []youtube.Video {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -129,7 +195,13 @@ func (s *Player) Run(ctx context.Context, wg *sync.WaitGroup) error {
 		return errors.New("player is already running")
 	}
 
-	s.setRunning(true)
+	ctx, stopFunc := context.WithCancelCause(ctx)
+	defer stopFunc(nil)
+
+	s.mu.Lock()
+	s.running = true
+	s.stopFunc = stopFunc
+	s.mu.Unlock()
 	defer s.setRunning(false)
 
 	wg.Done()
@@ -180,6 +252,7 @@ func (s *Player) Cleanup() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.queue = nil
+	s.updateQueueLength()
 	return s.vc.Disconnect()
 }
 
@@ -198,7 +271,7 @@ func (s *Player) ChannelID() string {
 
 func (s *Player) playAudio(ctx context.Context, ID string, vc *discordgo.VoiceConnection) error {
 	ytdlp := exec.Command(
-		"yt-dlp",
+		youtube.ExecutablePath(),
 		ID,
 		"--downloader", "ffmpeg",
 		"--no-part",
@@ -220,10 +293,11 @@ func (s *Player) playAudio(ctx context.Context, ID string, vc *discordgo.VoiceCo
 
 	options := dca.StdEncodeOptions
 	options.RawOutput = true
-	options.Bitrate = 64
+	options.Bitrate = s.options.Bitrate
 	options.Channels = 2
 	options.Application = dca.AudioApplicationAudio
-	options.VolumeFloat = -10.0
+	// dca passes VolumeFloat/10 to the ffmpeg volume filter
+	options.VolumeFloat = float32(s.options.Volume) / 10
 	options.VBR = true
 	options.Threads = 0
 	options.PacketLoss = 0
@@ -239,9 +313,15 @@ func (s *Player) playAudio(ctx context.Context, ID string, vc *discordgo.VoiceCo
 
 	err = ytdlp.Start()
 	if err != nil {
+		metrics.ProcessFailures.WithLabelValues(metrics.ProcessYtDlp).Inc()
 		return err
 	}
-	defer ytdlp.Wait()
+	defer func() {
+		// yt-dlp is killed when playback is cancelled, which isn't a failure
+		if err := ytdlp.Wait(); err != nil && ctx.Err() == nil {
+			metrics.ProcessFailures.WithLabelValues(metrics.ProcessYtDlp).Inc()
+		}
+	}()
 
 	select {
 	case <-ctx.Done():
@@ -261,6 +341,7 @@ func (s *Player) playAudio(ctx context.Context, ID string, vc *discordgo.VoiceCo
 				return nil
 			}
 
+			metrics.ProcessFailures.WithLabelValues(metrics.ProcessFFmpeg).Inc()
 			errBuf, _ := io.ReadAll(stderr)
 			s.logger.Error("error occured while playing audio", "ffmpeg messages", session.FFMPEGMessages(), "ytdlp", errBuf)
 
@@ -1,18 +1,19 @@
 package play
 
 import (
+	"context"
 	"fmt"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/LeBulldoge/gungus/internal/discord/embed"
 	"github.com/LeBulldoge/gungus/internal/discord/format"
+	"github.com/LeBulldoge/gungus/internal/discord/i18n"
 	"github.com/LeBulldoge/gungus/internal/youtube"
 	"github.com/bwmarrin/discordgo"
 )
 
-func (c *Command) HandleQueue(sesh *discordgo.Session, intr *discordgo.InteractionCreate) {
+func (c *Command) HandleQueue(_ context.Context, sesh *discordgo.Session, intr *discordgo.InteractionCreate) {
 	guildID := intr.GuildID
 
 	var queue []youtube.Video
@@ -20,7 +21,7 @@ func (c *Command) HandleQueue(sesh *discordgo.Session, intr *discordgo.Interacti
 		queue = ps.Queue()
 	}
 	if len(queue) < 1 {
-		format.DisplayInteractionError(sesh, intr, "There is nothing in the queue.")
+		format.DisplayInteractionError(sesh, intr, i18n.T(intr, "play.queue_empty"))
 		return
 	}
 
@@ -35,19 +36,19 @@ func (c *Command) HandleQueue(sesh *discordgo.Session, intr *discordgo.Interacti
 
 	currentVideo := queue[0]
 	embed := embed.NewEmbed().
-		SetAuthor("Currently playing").
+		SetAuthor(i18n.T(intr, "play.currently_playing")).
 		SetTitle(currentVideo.Title).
 		SetThumbnail(currentVideo.Thumbnail).
 		SetUrl(currentVideo.GetShortURL()).
 		SetDescription(currentVideo.Length).
-		SetFooter("Total count: "+strconv.Itoa(queueLength), "").
+		SetFooter(i18n.T(intr, "play.total_count", queueLength), "").
 		SetTimestamp(time.Now().Format(time.RFC3339))
 
 	fieldStart := 1
 	fieldEnd := 10
 
 	if queueLength > 1 {
-		embed.AddField("In queue", "")
+		embed.AddField(i18n.T(intr, "play.in_queue"), "")
 
 		var sb strings.Builder
 		maxLineLen := 1024 / 10
@@ -86,6 +87,6 @@ func (c *Command) HandleQueue(sesh *discordgo.Session, intr *discordgo.Interacti
 	})
 	if err != nil {
 		c.logger.Error("failure responding to interaction", "err", err)
-		format.DisplayInteractionError(sesh, intr, "Failure responding to interaction. See the log for details.")
+		format.DisplayInteractionError(sesh, intr, i18n.T(intr, "errors.responding"))
 	}
 }
@@ -3,10 +3,10 @@ package poll
 import (
 	"fmt"
 	"log/slog"
-	"strings"
 
 	"github.com/LeBulldoge/gungus/internal/database"
 	"github.com/LeBulldoge/gungus/internal/discord/bot"
+	"github.com/LeBulldoge/gungus/internal/discord/help"
 	"github.com/bwmarrin/discordgo"
 )
 
@@ -59,26 +59,14 @@ func buildPollCreateArgs() []*discordgo.ApplicationCommandOption {
 	return res
 }
 
+const voteNamespace = "poll"
+
 func (c *Command) Setup(bot *bot.Bot) error {
-	bot.Session.AddHandler(func(sesh *discordgo.Session, intr *discordgo.InteractionCreate) {
-		switch intr.Type {
-		case discordgo.InteractionApplicationCommand:
-			data := intr.ApplicationCommandData()
-			if data.Name != "poll" {
-				return
-			}
-			subData := intr.ApplicationCommandData().Options[0]
-			switch subData.Name {
-			case "start":
-				c.handlePoll(sesh, intr)
-			}
-		case discordgo.InteractionMessageComponent:
-			customID := intr.MessageComponentData().CustomID
-			if strings.HasPrefix(customID, "option") {
-				c.handleVote(sesh, intr)
-			}
-		}
-	})
+	bot.Router.HandleCommand("poll start", c.handlePoll)
+	// Votes aren't a command of their own, but rules for "poll vote" apply to them
+	bot.Router.WithCommand("poll vote").HandleComponent(voteNamespace, c.handleVote)
+	// Buttons of polls created before versioned custom IDs
+	bot.Router.WithCommand("poll vote").HandleComponent("option", c.handleVote)
 
 	return nil
 }
@@ -87,6 +75,12 @@ func (c *Command) Cleanup(bot *bot.Bot) error {
 	return nil
 }
 
+func (c *Command) GetExamples() []help.Example {
+	return []help.Example{
+		{Command: "poll start", Usage: "/poll start title:Dinner? option_0:🍕;Pizza option_1:🍣;Sushi", Description: "poll.examples.start"},
+	}
+}
+
 func (c *Command) AddLogger(logger *slog.Logger) {
 	if logger == nil {
 		return
@@ -1,6 +1,7 @@
 package poll
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"sort"
@@ -8,13 +9,17 @@ import (
 	"strings"
 	"time"
 
+	"github.com/LeBulldoge/gungus/internal/audit"
 	"github.com/LeBulldoge/gungus/internal/discord/embed"
 	"github.com/LeBulldoge/gungus/internal/discord/format"
+	"github.com/LeBulldoge/gungus/internal/discord/i18n"
+	"github.com/LeBulldoge/gungus/internal/discord/router"
 	"github.com/LeBulldoge/gungus/internal/poll"
+	"github.com/LeBulldoge/gungus/internal/settings"
 	"github.com/bwmarrin/discordgo"
 )
 
-func (c *Command) handlePoll(session *discordgo.Session, intr *discordgo.InteractionCreate) {
+func (c *Command) handlePoll(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
 	opt := intr.ApplicationCommandData().Options[0]
 	pollTitle := opt.Options[0].StringValue()
 
@@ -40,28 +45,28 @@ func (c *Command) handlePoll(session *discordgo.Session, intr *discordgo.Interac
 		spl := strings.Split(pollAnsText[i], ";")
 		if len(spl) < 2 {
 			logger.Error("incorrect formatting for poll option", "option", i)
-			format.DisplayInteractionError(session, intr, fmt.Sprintf("Incorrect formatting for option %d. <emoji> ; <description>", i))
+			format.DisplayInteractionError(session, intr, i18n.T(intr, "poll.invalid_option", i))
 			return
 		}
 
 		emojiStr, labelStr := spl[0], spl[1]
 
 		emoji := format.EmojiComponentFromString(emojiStr)
-		customID := fmt.Sprintf("option_%d_%s", i, strings.Trim(emojiStr, " "))
+		optionName := fmt.Sprintf("option_%d_%s", i, strings.Trim(emojiStr, " "))
 
 		btn := discordgo.Button{
-			CustomID: customID,
+			CustomID: router.NewCustomID(voteNamespace, optionName).String(),
 			Label:    labelStr,
 			Emoji:    emoji,
 			Style:    discordgo.SecondaryButton,
 		}
 
-		p.Options[btn.CustomID] = []string{}
+		p.Options[optionName] = []string{}
 
 		pollButtons = append(pollButtons, btn)
 	}
 
-	pollEmbed := buildEmbedFromPoll(p)
+	pollEmbed := buildEmbedFromPoll(intr, p, settings.PollBarLength.Get(ctx, c.Storage(), intr.GuildID))
 
 	err := session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
@@ -82,19 +87,25 @@ func (c *Command) handlePoll(session *discordgo.Session, intr *discordgo.Interac
 	msg, err := session.InteractionResponse(intr.Interaction)
 	if err != nil {
 		logger.Error("error collecting response for interaction", intr.ID, err)
-		format.DisplayInteractionError(session, intr, "Error saving poll in storage.")
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "poll.error_saving"), err)
 		return
 	}
 
 	p.ID = msg.ID
+	p.GuildID = intr.GuildID
 	err = c.Storage().AddPoll(p)
 	if err != nil {
 		logger.Error("failed storing poll", "err", err)
-		format.DisplayInteractionError(session, intr, "Error saving poll in storage.")
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "poll.error_saving"), err)
+		return
+	}
+
+	if err := audit.Record(ctx, c.Storage(), intr, "poll start", audit.Payload{"pollId": p.ID, "title": pollTitle, "options": pollAnsText}); err != nil {
+		logger.Error("failure recording audit entry", "err", err)
 	}
 }
 
-func (c *Command) handleVote(session *discordgo.Session, intr *discordgo.InteractionCreate) {
+func (c *Command) handleVote(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
 	err := session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseDeferredMessageUpdate,
 	})
@@ -103,34 +114,43 @@ func (c *Command) handleVote(session *discordgo.Session, intr *discordgo.Interac
 		return
 	}
 
-	voteCustomID := intr.MessageComponentData().CustomID
+	customID := intr.MessageComponentData().CustomID
 	logger := c.logger.With(
 		slog.Group(
 			"vote",
-			"customId", voteCustomID,
+			"customId", customID,
 			"pollId", intr.Message.ID,
 		),
 	)
 
-	err = c.Storage().CastVote(intr.Message.ID, voteCustomID, intr.Member.User.ID)
+	// Legacy buttons used the option name as their custom ID
+	optionName := customID
+	if id := router.ParseCustomID(customID); id.Version > 0 {
+		optionName = id.Arg(0)
+	}
+
+	err = c.Storage().CastVote(intr.GuildID, intr.Message.ID, optionName, intr.Member.User.ID)
 	if err != nil {
 		logger.Error("error casting vote", "err", err)
-		format.DisplayInteractionError(session, intr, "Error casting vote.")
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "poll.error_voting"), err)
 		return
 	}
+	if err := audit.Record(ctx, c.Storage(), intr, "poll vote", audit.Payload{"pollId": intr.Message.ID, "option": optionName}); err != nil {
+		logger.Error("failure recording audit entry", "err", err)
+	}
 
-	p, err := c.Storage().GetPoll(intr.Message.ID)
+	p, err := c.Storage().GetPoll(intr.GuildID, intr.Message.ID)
 	if err != nil {
 		logger.Error("error getting poll", "err", err)
-		format.DisplayInteractionError(session, intr, "Error getting poll from storage.")
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "poll.error_getting"), err)
 		return
 	}
 
-	pollEmbed := buildEmbedFromPoll(p)
+	pollEmbed := buildEmbedFromPoll(intr, p, settings.PollBarLength.Get(ctx, c.Storage(), intr.GuildID))
 	_, err = session.ChannelMessageEditEmbed(intr.ChannelID, intr.Message.ID, pollEmbed)
 	if err != nil {
 		logger.Error("error editing message", "err", err)
-		format.DisplayInteractionError(session, intr, "Error editing message.")
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "poll.error_editing"), err)
 	}
 }
 
@@ -139,7 +159,9 @@ const (
 	full  = "🔲"
 )
 
-func buildEmbedFromPoll(p poll.Poll) *discordgo.MessageEmbed {
+// buildEmbedFromPoll shows the results of a poll in the language of the guild of intr,
+// since everyone sees them.
+func buildEmbedFromPoll(intr *discordgo.InteractionCreate, p poll.Poll, barLength int) *discordgo.MessageEmbed {
 	e := embed.NewEmbed().
 		SetTitle(p.Title)
 
@@ -158,8 +180,8 @@ func buildEmbedFromPoll(p poll.Poll) *discordgo.MessageEmbed {
 	for _, option := range options {
 		count := votes[option]
 
-		res := (float64(count) / float64(total)) * 10
-		for i := 0; i < 10; i++ {
+		res := (float64(count) / float64(total)) * float64(barLength)
+		for i := 0; i < barLength; i++ {
 			if i < int(res) {
 				sb.WriteString(full)
 			} else {
@@ -178,10 +200,7 @@ func buildEmbedFromPoll(p poll.Poll) *discordgo.MessageEmbed {
 		sb.Reset()
 	}
 
-	sb.WriteString("Total votes: ")
-	sb.WriteString(strconv.Itoa(total))
-
-	e.SetFooter(sb.String(), "")
+	e.SetFooter(i18n.TGuild(intr, "poll.total_votes", total), "")
 	e.SetTimestamp(time.Now().Format(time.RFC3339))
 
 	return e.MessageEmbed
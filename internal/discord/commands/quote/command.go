@@ -2,15 +2,28 @@ package quote
 
 import (
 	"log/slog"
+	"sync"
 
 	"github.com/LeBulldoge/gungus/internal/database"
 	"github.com/LeBulldoge/gungus/internal/discord/bot"
+	"github.com/LeBulldoge/gungus/internal/discord/help"
+	"github.com/LeBulldoge/gungus/internal/discord/ops"
+	"github.com/LeBulldoge/gungus/internal/quote"
 	"github.com/bwmarrin/discordgo"
 )
 
+// saveCommand is the message context menu command saving a message as a quote.
+const saveCommand = "Save as quote"
+
+var idMinValue = 1.0
+
 type Command struct {
 	database.WithStorage
 
+	// daily is the quote of the day scheduler, stopped by the cancelled bot context
+	daily sync.WaitGroup
+
+	ops    *ops.Reporter
 	logger *slog.Logger
 }
 
@@ -43,6 +56,153 @@ func (c *Command) GetSignature() []*discordgo.ApplicationCommand {
 						},
 					},
 				},
+				{
+					Name:        "conversation",
+					Description: "Save a conversation between several people",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "speaker1",
+							Description: "Member taking part, named in the lines by their name",
+							Type:        discordgo.ApplicationCommandOptionUser,
+						},
+						{
+							Name:        "speaker2",
+							Description: "Member taking part, named in the lines by their name",
+							Type:        discordgo.ApplicationCommandOptionUser,
+						},
+						{
+							Name:        "speaker3",
+							Description: "Member taking part, named in the lines by their name",
+							Type:        discordgo.ApplicationCommandOptionUser,
+						},
+					},
+				},
+				{
+					Name:        "show",
+					Description: "Show a quote by its number",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "id",
+							Description: "Number of the quote",
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Required:    true,
+							MinValue:    &idMinValue,
+						},
+					},
+				},
+				{
+					Name:        "edit",
+					Description: "Correct the text or the author of a quote",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "id",
+							Description: "Number of the quote",
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Required:    true,
+							MinValue:    &idMinValue,
+						},
+						{
+							Name:        "text",
+							Description: "New quote text",
+							Type:        discordgo.ApplicationCommandOptionString,
+						},
+						{
+							Name:        "by_user",
+							Description: "New user attribution",
+							Type:        discordgo.ApplicationCommandOptionUser,
+						},
+					},
+				},
+				{
+					Name:        "delete",
+					Description: "Delete a quote",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "id",
+							Description: "Number of the quote",
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Required:    true,
+							MinValue:    &idMinValue,
+						},
+					},
+				},
+				{
+					Name:        "search",
+					Description: "Search quotes by their text",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:         "text",
+							Description:  "Words the quotes contain",
+							Type:         discordgo.ApplicationCommandOptionString,
+							Required:     true,
+							Autocomplete: true,
+							MaxLength:    searchTextLength,
+						},
+						{
+							Name:        "by_user",
+							Description: "Only search quotes by this user",
+							Type:        discordgo.ApplicationCommandOptionUser,
+						},
+					},
+				},
+				{
+					Name:        "top",
+					Description: "Show the quotes with the most votes",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "by_user",
+							Description: "Only show quotes by this user",
+							Type:        discordgo.ApplicationCommandOptionUser,
+						},
+						{
+							Name:        "period",
+							Description: "Only show quotes saved in this period",
+							Type:        discordgo.ApplicationCommandOptionString,
+							Choices:     periodChoices(),
+						},
+					},
+				},
+				{
+					Name:        "leaderboard",
+					Description: "Show the most quoted members",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+				},
+				{
+					Name:        "daily",
+					Description: "Schedule a quote of the day",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:         "channel",
+							Description:  "Channel to post the quote in",
+							Type:         discordgo.ApplicationCommandOptionChannel,
+							ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText, discordgo.ChannelTypeGuildNews},
+						},
+						{
+							Name:        "time",
+							Description: "Time of day to post at, like 09:00",
+							Type:        discordgo.ApplicationCommandOptionString,
+							MaxLength:   len(quote.DailyTimeLayout),
+						},
+						{
+							Name:         "timezone",
+							Description:  "Timezone of the time, like Europe/Berlin",
+							Type:         discordgo.ApplicationCommandOptionString,
+							Autocomplete: true,
+						},
+						{
+							Name:        "enabled",
+							Description: "Turn the quote of the day on or off",
+							Type:        discordgo.ApplicationCommandOptionBoolean,
+						},
+					},
+				},
 				{
 					Name:        "random",
 					Description: "Get a random quote by a particular user",
@@ -57,6 +217,22 @@ func (c *Command) GetSignature() []*discordgo.ApplicationCommand {
 				},
 			},
 		},
+		{
+			Name: saveCommand,
+			Type: discordgo.MessageApplicationCommand,
+		},
+	}
+}
+
+func (c *Command) GetExamples() []help.Example {
+	return []help.Example{
+		{Command: "quote add", Usage: "/quote add by_user:@Gungus text:This is a quote", Description: "quote.examples.add"},
+		{Command: "quote conversation", Usage: "/quote conversation speaker1:@Gungus speaker2:@Bungus", Description: "quote.examples.conversation"},
+		{Command: "quote random", Usage: "/quote random", Description: "quote.examples.random"},
+		{Command: "quote search", Usage: "/quote search text:pizza by_user:@Gungus", Description: "quote.examples.search"},
+		{Command: "quote edit", Usage: "/quote edit id:12 text:This is the right quote", Description: "quote.examples.edit"},
+		{Command: "quote top", Usage: "/quote top period:Past month", Description: "quote.examples.top"},
+		{Command: "quote daily", Usage: "/quote daily channel:#general time:09:00 timezone:Europe/Berlin", Description: "quote.examples.daily"},
 	}
 }
 
@@ -69,26 +245,37 @@ func (c *Command) AddLogger(logger *slog.Logger) {
 }
 
 func (c *Command) Setup(bot *bot.Bot) error {
-	bot.Session.AddHandler(func(sesh *discordgo.Session, intr *discordgo.InteractionCreate) {
-		switch intr.Type {
-		case discordgo.InteractionApplicationCommand:
-			data := intr.ApplicationCommandData()
-			if data.Name != "quote" {
-				return
-			}
-			subData := intr.ApplicationCommandData().Options[0]
-			switch subData.Name {
-			case "add":
-				c.addQuote(sesh, intr)
-			case "random":
-				c.randomQuote(sesh, intr)
-			}
-		}
-	})
+	c.ops = bot.Ops
+
+	bot.Router.HandleCommand("quote add", c.addQuote)
+	bot.Router.HandleCommand("quote conversation", c.newConversation)
+	bot.Router.WithCommand("quote conversation").HandleModal(conversationNamespace, c.saveConversation)
+	bot.Router.HandleCommand("quote show", c.showQuote)
+	bot.Router.HandleCommand("quote edit", c.editQuote)
+	bot.Router.HandleCommand("quote delete", c.deleteQuote)
+	bot.Router.HandleCommand("quote search", c.searchQuotes)
+	bot.Router.HandleAutocomplete("quote search", "text", c.searchAutocomplete)
+	bot.Router.WithCommand("quote search").HandleComponent(searchNamespace, c.searchPaginate)
+	bot.Router.HandleCommand("quote top", c.topQuotes)
+	bot.Router.HandleCommand("quote leaderboard", c.leaderboard)
+	// Votes aren't a command of their own, but rules for "quote vote" apply to them
+	bot.Router.WithCommand("quote vote").HandleComponent(voteNamespace, c.voteQuote)
+	bot.Router.HandleCommand("quote daily", c.configureDaily)
+	bot.Router.HandleAutocomplete("quote daily", "timezone", c.timezoneAutocomplete)
+	bot.Router.HandleCommand("quote random", c.randomQuote)
+	bot.Router.HandleCommand(saveCommand, c.saveQuote)
+
+	c.daily.Add(1)
+	go func() {
+		defer c.daily.Done()
+		c.runDaily(bot.Context(), bot.Session)
+	}()
 
 	return nil
 }
 
+// Cleanup waits for the quote of the day scheduler to stop.
 func (c *Command) Cleanup(bot *bot.Bot) error {
+	c.daily.Wait()
 	return nil
 }
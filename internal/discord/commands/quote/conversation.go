@@ -0,0 +1,196 @@
+package quote
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/LeBulldoge/gungus/internal/audit"
+	"github.com/LeBulldoge/gungus/internal/discord/format"
+	"github.com/LeBulldoge/gungus/internal/discord/i18n"
+	"github.com/LeBulldoge/gungus/internal/discord/router"
+	"github.com/LeBulldoge/gungus/internal/quote"
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	conversationNamespace = "quoteconversation"
+	// conversationLength keeps conversations, with their speakers mentioned, within the length of a message
+	conversationLength = 1500
+)
+
+// mentionPattern matches a user mention, like "<@123>".
+var mentionPattern = regexp.MustCompile(`^<@!?(\d+)>$`)
+
+// speaker renders the speaker of l, mentioning members.
+func speaker(l quote.Line) string {
+	if len(l.User) > 0 {
+		return "<@" + l.User + ">"
+	}
+	return "**" + l.Name + "**"
+}
+
+// speakers lists everyone speaking in lines once, in the order they first speak.
+func speakers(lines []quote.Line) string {
+	seen := map[string]bool{}
+	list := []string{}
+	for _, l := range lines {
+		s := speaker(l)
+		if !seen[s] {
+			seen[s] = true
+			list = append(list, s)
+		}
+	}
+	return strings.Join(list, ", ")
+}
+
+// newConversation asks for the lines of a conversation in a modal. The members chosen
+// as speakers are kept in its custom ID, so lines can name them.
+func (c *Command) newConversation(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	opt := intr.ApplicationCommandData().Options[0]
+
+	speakerIDs := []string{}
+	for _, o := range opt.Options {
+		speakerIDs = append(speakerIDs, o.UserValue(nil).ID)
+	}
+
+	err := session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID: router.NewCustomID(conversationNamespace, speakerIDs...).String(),
+			Title:    i18n.T(intr, "quote.conversation_title"),
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:    "lines",
+							Label:       i18n.T(intr, "quote.conversation_label"),
+							Style:       discordgo.TextInputParagraph,
+							Placeholder: i18n.T(intr, "quote.conversation_placeholder"),
+							Required:    true,
+							MaxLength:   conversationLength,
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		c.logger.Error("error responding to interaction", "err", err)
+	}
+}
+
+func (c *Command) saveConversation(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	data := intr.ModalSubmitData()
+
+	var text string
+	for _, row := range data.Components {
+		if row, ok := row.(*discordgo.ActionsRow); ok {
+			for _, comp := range row.Components {
+				if input, ok := comp.(*discordgo.TextInput); ok && input.CustomID == "lines" {
+					text = input.Value
+				}
+			}
+		}
+	}
+
+	log := c.logger.With(slog.Group("conversation", "guildId", intr.GuildID))
+
+	resolve := c.speakerResolver(session, intr.GuildID, router.ParseCustomID(data.CustomID).Args)
+	lines, err := quote.ParseLines(text, resolve)
+	if errors.Is(err, quote.ErrNoSpeaker) || errors.Is(err, quote.ErrNoLines) {
+		format.DisplayInteractionError(session, intr, i18n.T(intr, "quote.conversation_invalid"))
+		return
+	}
+	if err != nil {
+		log.Error("failure reading a conversation", "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "quote.error_saving"), err)
+		return
+	}
+
+	q := quote.Quote{
+		Text:        quote.Dialogue(lines),
+		Date:        time.Now(),
+		GuildID:     intr.GuildID,
+		SubmittedBy: intr.Member.User.ID,
+		Lines:       lines,
+	}
+	for _, l := range lines {
+		if len(l.User) > 0 {
+			q.User = l.User
+			break
+		}
+	}
+
+	q.ID, err = quote.AddQuote(ctx, c.Storage(), q)
+	if err != nil {
+		log.Error("failed saving a quote", "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "quote.error_saving"), err)
+		return
+	}
+	if err := audit.Record(ctx, c.Storage(), intr, "quote conversation", audit.Payload{"quoteId": q.ID, "text": q.Text}); err != nil {
+		log.Error("failure recording audit entry", "err", err)
+	}
+
+	err = session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content:         i18n.T(intr, "quote.saved_conversation", q.ID) + "\n\n" + formatQuote(i18n.Chain(intr), q, "", nil),
+			Flags:           discordgo.MessageFlagsEphemeral,
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		},
+	})
+	if err != nil {
+		log.Error("error responding to interaction", "err", err)
+		return
+	}
+
+	log.Info("conversation saved", "quoteId", q.ID, "lines", len(lines))
+}
+
+// speakerResolver returns the resolver of the speakers of a conversation for quote.ParseLines.
+// Speakers named like one of the members speakerIDs, ignoring case, or mentioning a member
+// are those members, anyone else is a non-member known by name.
+func (c *Command) speakerResolver(session *discordgo.Session, guildID string, speakerIDs []string) func(name string) (string, string) {
+	member := func(id string) *discordgo.Member {
+		m, err := session.State.Member(guildID, id)
+		if err != nil {
+			m, err = session.GuildMember(guildID, id)
+		}
+		if err != nil {
+			c.logger.Error("failure getting member data", "userId", id, "err", err)
+			return nil
+		}
+		return m
+	}
+
+	members := map[string]*discordgo.Member{}
+	for _, id := range speakerIDs {
+		m := member(id)
+		if m == nil || m.User == nil {
+			continue
+		}
+		for _, name := range []string{m.Nick, m.User.GlobalName, m.User.Username} {
+			if len(name) > 0 {
+				members[strings.ToLower(name)] = m
+			}
+		}
+	}
+
+	return func(name string) (string, string) {
+		if match := mentionPattern.FindStringSubmatch(name); match != nil {
+			if m := member(match[1]); m != nil && m.User != nil {
+				return m.User.ID, m.DisplayName()
+			}
+			return match[1], name
+		}
+
+		if m, ok := members[strings.ToLower(strings.TrimPrefix(name, "@"))]; ok {
+			return m.User.ID, m.DisplayName()
+		}
+		return "", name
+	}
+}
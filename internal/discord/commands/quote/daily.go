@@ -0,0 +1,281 @@
+package quote
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/LeBulldoge/gungus/internal/audit"
+	"github.com/LeBulldoge/gungus/internal/discord/format"
+	"github.com/LeBulldoge/gungus/internal/discord/i18n"
+	"github.com/LeBulldoge/gungus/internal/discord/ops"
+	"github.com/LeBulldoge/gungus/internal/permission"
+	"github.com/LeBulldoge/gungus/internal/quote"
+	"github.com/LeBulldoge/gungus/internal/settings"
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	defaultDailyTime     = "09:00"
+	defaultDailyTimezone = "UTC"
+	// dailyInterval is how often the schedules are checked for due posts
+	dailyInterval = time.Minute
+)
+
+// timezones are suggested while typing a timezone, any IANA name is accepted.
+var timezones = []string{
+	"UTC",
+	"Africa/Cairo", "Africa/Johannesburg", "Africa/Lagos",
+	"America/Anchorage", "America/Argentina/Buenos_Aires", "America/Bogota", "America/Chicago",
+	"America/Denver", "America/Halifax", "America/Los_Angeles", "America/Mexico_City",
+	"America/New_York", "America/Phoenix", "America/Sao_Paulo", "America/Toronto",
+	"Asia/Bangkok", "Asia/Dubai", "Asia/Hong_Kong", "Asia/Jakarta", "Asia/Kolkata",
+	"Asia/Seoul", "Asia/Shanghai", "Asia/Singapore", "Asia/Tokyo",
+	"Australia/Adelaide", "Australia/Brisbane", "Australia/Perth", "Australia/Sydney",
+	"Europe/Amsterdam", "Europe/Berlin", "Europe/Istanbul", "Europe/Kyiv", "Europe/London",
+	"Europe/Madrid", "Europe/Moscow", "Europe/Paris", "Europe/Rome", "Europe/Stockholm", "Europe/Warsaw",
+	"Pacific/Auckland", "Pacific/Honolulu",
+}
+
+func (c *Command) configureDaily(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	if !permission.CanManage(ctx, intr.Member) {
+		format.DisplayInteractionError(session, intr, i18n.T(intr, "quote.daily_manage_only"))
+		return
+	}
+
+	opt := intr.ApplicationCommandData().Options[0]
+	log := c.logger.With(slog.Group("daily", "guildId", intr.GuildID))
+
+	d, err := quote.GetDaily(ctx, c.Storage(), intr.GuildID)
+	exists := err == nil
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		log.Error("failure getting the quote of the day schedule", "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "quote.daily_error_getting"), err)
+		return
+	}
+	if !exists {
+		d = quote.Daily{
+			GuildID:   intr.GuildID,
+			ChannelID: intr.ChannelID,
+			Time:      defaultDailyTime,
+			Timezone:  defaultDailyTimezone,
+		}
+	}
+
+	if len(opt.Options) == 0 {
+		c.respondDaily(session, intr, d, exists, log)
+		return
+	}
+
+	for _, o := range opt.Options {
+		switch o.Name {
+		case "enabled":
+			if !o.BoolValue() {
+				c.disableDaily(ctx, session, intr, log)
+				return
+			}
+		case "channel":
+			d.ChannelID = o.ChannelValue(nil).ID
+		case "time":
+			t, err := time.Parse(quote.DailyTimeLayout, strings.TrimSpace(o.StringValue()))
+			if err != nil {
+				format.DisplayInteractionError(session, intr, i18n.T(intr, "quote.daily_invalid_time", o.StringValue()))
+				return
+			}
+			d.Time = t.Format(quote.DailyTimeLayout)
+		case "timezone":
+			loc, err := time.LoadLocation(strings.TrimSpace(o.StringValue()))
+			if err != nil || loc.String() == "Local" {
+				format.DisplayInteractionError(session, intr, i18n.T(intr, "quote.daily_invalid_timezone", o.StringValue()))
+				return
+			}
+			d.Timezone = loc.String()
+		}
+	}
+
+	// A time of day that already passed today starts tomorrow instead of posting right away
+	now := time.Now()
+	if due, _ := d.Due(now); due {
+		d.LastPostedOn = d.Today(now)
+	}
+
+	if err := quote.SetDaily(ctx, c.Storage(), d); err != nil {
+		log.Error("failure saving the quote of the day schedule", "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "quote.daily_error_saving"), err)
+		return
+	}
+	payload := audit.Payload{"channelId": d.ChannelID, "time": d.Time, "timezone": d.Timezone}
+	if err := audit.Record(ctx, c.Storage(), intr, "quote daily", payload); err != nil {
+		log.Error("failure recording audit entry", "err", err)
+	}
+
+	c.respondDaily(session, intr, d, true, log)
+	log.Info("quote of the day scheduled", "channelId", d.ChannelID, "time", d.Time, "timezone", d.Timezone)
+}
+
+func (c *Command) disableDaily(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate, log *slog.Logger) {
+	deleted, err := quote.DeleteDaily(ctx, c.Storage(), intr.GuildID)
+	if err != nil {
+		log.Error("failure deleting the quote of the day schedule", "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "quote.daily_error_saving"), err)
+		return
+	}
+	if deleted {
+		if err := audit.Record(ctx, c.Storage(), intr, "quote daily", audit.Payload{"enabled": false}); err != nil {
+			log.Error("failure recording audit entry", "err", err)
+		}
+	}
+
+	c.respondDaily(session, intr, quote.Daily{}, false, log)
+}
+
+func (c *Command) respondDaily(session *discordgo.Session, intr *discordgo.InteractionCreate, d quote.Daily, enabled bool, log *slog.Logger) {
+	content := i18n.T(intr, "quote.daily_off")
+	if enabled {
+		content = i18n.T(intr, "quote.daily_on", "<#"+d.ChannelID+">", d.Time, d.Timezone)
+		if next, err := d.Next(time.Now()); err == nil {
+			content += "\n" + i18n.T(intr, "quote.daily_next", fmt.Sprintf("<t:%d:R>", next.Unix()))
+		}
+	}
+
+	err := session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Error("error responding to interaction", "err", err)
+	}
+}
+
+func (c *Command) timezoneAutocomplete(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	var query string
+	for _, opt := range intr.ApplicationCommandData().Options[0].Options {
+		if opt.Focused {
+			query = strings.ToLower(opt.StringValue())
+		}
+	}
+
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	for _, tz := range timezones {
+		if strings.Contains(strings.ToLower(tz), query) && len(choices) < 25 {
+			choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+				Name:  tz,
+				Value: tz,
+			})
+		}
+	}
+
+	err := session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+	if err != nil {
+		c.logger.Error("failure responding to autocomplete", "err", err)
+	}
+}
+
+// runDaily posts the quotes of the day that are due until ctx is done.
+func (c *Command) runDaily(ctx context.Context, session *discordgo.Session) {
+	tick := time.NewTicker(dailyInterval)
+	defer tick.Stop()
+
+	for {
+		c.postDueDailies(ctx, session)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+		}
+	}
+}
+
+func (c *Command) postDueDailies(ctx context.Context, session *discordgo.Session) {
+	dailies, err := quote.GetDailies(ctx, c.Storage())
+	if err != nil {
+		c.logger.Error("failure getting the quote of the day schedules", "err", err)
+		return
+	}
+
+	now := time.Now()
+	for _, d := range dailies {
+		due, err := d.Due(now)
+		if err != nil {
+			c.logger.Error("invalid quote of the day schedule", "guildId", d.GuildID, "err", err)
+			continue
+		}
+		if !due || !settings.FeatureEnabled(ctx, c.Storage(), d.GuildID, "quote") {
+			continue
+		}
+
+		// The day counts as done even if posting fails, reporting it once instead of every minute
+		if err := quote.SetDailyPostedOn(ctx, c.Storage(), d.GuildID, d.Today(now)); err != nil {
+			c.logger.Error("failure updating the quote of the day schedule", "guildId", d.GuildID, "err", err)
+			continue
+		}
+		if err := c.postDaily(ctx, session, d); err != nil {
+			c.logger.Error("failure posting the quote of the day", "guildId", d.GuildID, "channelId", d.ChannelID, "err", err)
+			c.ops.Report(ops.Report{
+				Title:   "Quote of the day failed",
+				ID:      ops.NewCorrelationID(),
+				GuildID: d.GuildID,
+				Command: "quote daily",
+				Err:     err,
+			})
+		}
+	}
+}
+
+// postDaily posts a quote that wasn't posted yet to the channel of d.
+func (c *Command) postDaily(ctx context.Context, session *discordgo.Session, d quote.Daily) error {
+	quotes, err := quote.GetQuotes(ctx, c.Storage(), d.GuildID)
+	if err != nil {
+		return err
+	}
+	posted, err := quote.GetDailyPosted(ctx, c.Storage(), d.GuildID)
+	if err != nil {
+		return err
+	}
+
+	q, reset, ok := quote.PickDaily(quotes, posted, rand.Intn)
+	if !ok {
+		c.logger.Info("no quotes for the quote of the day", "guildId", d.GuildID)
+		return nil
+	}
+
+	var chain []discordgo.Locale
+	if guild, err := session.State.Guild(d.GuildID); err == nil && len(guild.PreferredLocale) > 0 {
+		chain = append(chain, discordgo.Locale(guild.PreferredLocale))
+	}
+
+	votes, err := quote.GetVotes(ctx, c.Storage(), q.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = session.ChannelMessageSendComplex(d.ChannelID, &discordgo.MessageSend{
+		Content:    i18n.Format(chain, "quote.daily_title") + "\n\n" + formatQuote(chain, q, "<@"+q.User+">", c.attachmentURLs(session, q)),
+		Flags:      messageFlagsSilent,
+		Components: voteButtons(q.ID, votes),
+	})
+	if err != nil {
+		return fmt.Errorf("failure sending the quote of the day: %w", err)
+	}
+
+	if err := quote.AddDailyPosted(ctx, c.Storage(), d.GuildID, q.ID, reset); err != nil {
+		return err
+	}
+
+	c.logger.Info("quote of the day posted", "guildId", d.GuildID, "quoteId", q.ID, "reset", reset)
+	return nil
+}
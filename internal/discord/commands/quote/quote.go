@@ -2,17 +2,23 @@ package quote
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
-	"math/rand"
+	"strings"
 	"time"
 
+	"github.com/LeBulldoge/gungus/internal/audit"
 	"github.com/LeBulldoge/gungus/internal/discord/format"
+	"github.com/LeBulldoge/gungus/internal/discord/i18n"
+	"github.com/LeBulldoge/gungus/internal/permission"
 	"github.com/LeBulldoge/gungus/internal/quote"
+	"github.com/LeBulldoge/gungus/internal/settings"
 	"github.com/bwmarrin/discordgo"
 )
 
-func (c *Command) addQuote(session *discordgo.Session, intr *discordgo.InteractionCreate) {
+func (c *Command) addQuote(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
 	opt := intr.ApplicationCommandData().Options[0]
 
 	byUser := opt.Options[0].UserValue(session)
@@ -26,17 +32,26 @@ func (c *Command) addQuote(session *discordgo.Session, intr *discordgo.Interacti
 		),
 	)
 
-	err := quote.AddQuote(context.TODO(), c.Storage(), byUser.ID, quoteText, time.Now())
+	id, err := quote.AddQuote(ctx, c.Storage(), quote.Quote{
+		User:        byUser.ID,
+		Text:        quoteText,
+		Date:        time.Now(),
+		GuildID:     intr.GuildID,
+		SubmittedBy: intr.Member.User.ID,
+	})
 	if err != nil {
 		log.Error("failed saving a quote", "err", err)
-		format.DisplayInteractionError(session, intr, "Error saving a quote.")
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "quote.error_saving"), err)
 		return
 	}
+	if err := audit.Record(ctx, c.Storage(), intr, "quote add", audit.Payload{"quoteId": id, "byUser": byUser.ID, "text": quoteText}); err != nil {
+		log.Error("failure recording audit entry", "err", err)
+	}
 
 	err = session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Content: fmt.Sprintf("Quote by user %s saved.", byUser.Mention()),
+			Content: i18n.T(intr, "quote.saved", id, byUser.Mention()),
 			Flags:   discordgo.MessageFlagsEphemeral,
 		},
 	})
@@ -48,17 +63,247 @@ func (c *Command) addQuote(session *discordgo.Session, intr *discordgo.Interacti
 	log.Info("quote added")
 }
 
-func (c *Command) randomQuote(session *discordgo.Session, intr *discordgo.InteractionCreate) {
+func (c *Command) saveQuote(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	data := intr.ApplicationCommandData()
+	msg, ok := data.Resolved.Messages[data.TargetID]
+	if !ok || msg.Author == nil {
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "quote.error_saving"), fmt.Errorf("message %s is missing from the interaction", data.TargetID))
+		return
+	}
+
+	q := quote.Quote{
+		User:        msg.Author.ID,
+		Text:        msg.Content,
+		Date:        msg.Timestamp,
+		GuildID:     intr.GuildID,
+		ChannelID:   intr.ChannelID,
+		MessageID:   msg.ID,
+		SubmittedBy: intr.Member.User.ID,
+	}
+	for _, a := range msg.Attachments {
+		q.Attachments = append(q.Attachments, a.ID)
+	}
+
+	log := c.logger.With(
+		slog.Group(
+			"save",
+			"byUser", q.User,
+			"messageId", q.MessageID,
+			"attachments", len(q.Attachments),
+		),
+	)
+
+	if len(q.Text) == 0 && len(q.Attachments) == 0 {
+		format.DisplayInteractionError(session, intr, i18n.T(intr, "quote.empty_message"))
+		return
+	}
+
+	id, err := quote.AddQuote(ctx, c.Storage(), q)
+	if err != nil {
+		log.Error("failed saving a quote", "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "quote.error_saving"), err)
+		return
+	}
+	if err := audit.Record(ctx, c.Storage(), intr, "quote save", audit.Payload{"quoteId": id, "byUser": q.User, "messageId": q.MessageID, "text": q.Text}); err != nil {
+		log.Error("failure recording audit entry", "err", err)
+	}
+
+	err = session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content:         i18n.T(intr, "quote.saved_message", id, msg.Author.Mention(), q.Link()),
+			Flags:           discordgo.MessageFlagsEphemeral,
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		},
+	})
+	if err != nil {
+		log.Error("error responding to interaction", "err", err)
+		return
+	}
+
+	log.Info("quote saved from message")
+}
+
+func (c *Command) showQuote(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
 	opt := intr.ApplicationCommandData().Options[0]
+	id := opt.Options[0].IntValue()
+
+	log := c.logger.With(slog.Group("show", "id", id))
+
+	q, ok := c.findQuote(ctx, session, intr, id, log)
+	if !ok {
+		return
+	}
 
+	var mention string
+	if len(q.Lines) == 0 {
+		user, err := session.User(q.User)
+		if err != nil {
+			log.Error("failure getting user data", "err", err)
+			format.DisplayInteractionWithError(session, intr, i18n.T(intr, "quote.error_user"), err)
+			return
+		}
+		mention = user.Mention()
+	}
+
+	err := session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: formatQuote(i18n.Chain(intr), q, mention, c.attachmentURLs(session, q)),
+			Flags:   c.quoteFlags(ctx, intr.GuildID),
+		},
+	})
+	if err != nil {
+		log.Error("error responding to interaction", "err", err)
+	}
+}
+
+func (c *Command) editQuote(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	opt := intr.ApplicationCommandData().Options[0]
+
+	var id int64
+	var text string
 	var byUser *discordgo.User
-	var quotes []quote.Quote
-	var err error
+	for _, o := range opt.Options {
+		switch o.Name {
+		case "id":
+			id = o.IntValue()
+		case "text":
+			text = o.StringValue()
+		case "by_user":
+			byUser = o.UserValue(nil)
+		}
+	}
+
+	log := c.logger.With(slog.Group("edit", "id", id))
+
+	q, ok := c.findQuote(ctx, session, intr, id, log)
+	if !ok {
+		return
+	}
+	if !canChange(ctx, intr.Member, q) {
+		log.Info("user may not change the quote", "user", intr.Member.User.ID)
+		format.DisplayInteractionError(session, intr, i18n.T(intr, "quote.change_not_allowed"))
+		return
+	}
+
+	if len(q.Lines) > 0 {
+		format.DisplayInteractionError(session, intr, i18n.T(intr, "quote.edit_conversation"))
+		return
+	}
+
+	before := q
+	if len(text) > 0 {
+		q.Text = text
+	}
+	if byUser != nil {
+		q.User = byUser.ID
+	}
+	if q.Text == before.Text && q.User == before.User {
+		format.DisplayInteractionError(session, intr, i18n.T(intr, "quote.edit_nothing"))
+		return
+	}
+
+	if err := quote.UpdateQuote(ctx, c.Storage(), q); err != nil {
+		log.Error("failure updating quote", "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "quote.error_editing"), err)
+		return
+	}
+	payload := audit.Payload{"quoteId": q.ID, "byUser": q.User, "text": q.Text, "oldByUser": before.User, "oldText": before.Text}
+	if err := audit.Record(ctx, c.Storage(), intr, "quote edit", payload); err != nil {
+		log.Error("failure recording audit entry", "err", err)
+	}
+
+	err := session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: i18n.T(intr, "quote.edited", q.ID) + "\n\n" + formatQuote(i18n.Chain(intr), q, "<@"+q.User+">", c.attachmentURLs(session, q)),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Error("error responding to interaction", "err", err)
+		return
+	}
+
+	log.Info("quote edited")
+}
+
+func (c *Command) deleteQuote(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	opt := intr.ApplicationCommandData().Options[0]
+	id := opt.Options[0].IntValue()
+
+	log := c.logger.With(slog.Group("delete", "id", id))
+
+	q, ok := c.findQuote(ctx, session, intr, id, log)
+	if !ok {
+		return
+	}
+	if !canChange(ctx, intr.Member, q) {
+		log.Info("user may not change the quote", "user", intr.Member.User.ID)
+		format.DisplayInteractionError(session, intr, i18n.T(intr, "quote.change_not_allowed"))
+		return
+	}
+
+	deleted, err := quote.DeleteQuote(ctx, c.Storage(), intr.GuildID, id)
+	if err != nil {
+		log.Error("failure deleting quote", "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "quote.error_deleting"), err)
+		return
+	}
+	if !deleted {
+		format.DisplayInteractionError(session, intr, i18n.T(intr, "quote.not_found", id))
+		return
+	}
+	if err := audit.Record(ctx, c.Storage(), intr, "quote delete", audit.Payload{"quoteId": q.ID, "byUser": q.User, "text": q.Text}); err != nil {
+		log.Error("failure recording audit entry", "err", err)
+	}
+
+	err = session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: i18n.T(intr, "quote.deleted", id),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Error("error responding to interaction", "err", err)
+		return
+	}
+
+	log.Info("quote deleted")
+}
+
+// findQuote gets the quote id of the guild, telling the user if there is none.
+func (c *Command) findQuote(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate, id int64, log *slog.Logger) (quote.Quote, bool) {
+	q, err := quote.GetQuote(ctx, c.Storage(), intr.GuildID, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		format.DisplayInteractionError(session, intr, i18n.T(intr, "quote.not_found", id))
+		return q, false
+	}
+	if err != nil {
+		log.Error("failure getting quote", "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "quote.error_getting"), err)
+		return q, false
+	}
+
+	return q, true
+}
+
+// canChange reports whether member may edit or delete q: the user who saved it and
+// the quoted users, every member speaking in conversations, can, as can moderators.
+func canChange(ctx context.Context, member *discordgo.Member, q quote.Quote) bool {
+	return member.User.ID == q.SubmittedBy || speaks(q, member.User.ID) || permission.CanModerate(ctx, member)
+}
+
+func (c *Command) randomQuote(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	opt := intr.ApplicationCommandData().Options[0]
+
+	var byUser *discordgo.User
+	var userID string
 	if len(opt.Options) > 0 {
 		byUser = opt.Options[0].UserValue(session)
-		quotes, err = quote.GetQuotesByUser(context.TODO(), c.Storage(), byUser.ID)
-	} else {
-		quotes, err = quote.GetQuotes(context.TODO(), c.Storage())
+		userID = byUser.ID
 	}
 
 	log := c.logger.With(
@@ -68,47 +313,53 @@ func (c *Command) randomQuote(session *discordgo.Session, intr *discordgo.Intera
 		),
 	)
 
-	if err != nil {
-		log.Error("failure getting quotes", "err", err)
-		format.DisplayInteractionError(session, intr, "Error getting quotes.")
-		return
+	opts := quote.BagOptions{
+		Weighted: settings.QuoteWeighted.Get(ctx, c.Storage(), intr.GuildID),
+		Recent:   settings.QuoteRecent.Get(ctx, c.Storage(), intr.GuildID),
 	}
-
-	if len(quotes) == 0 {
-		log.Error("no quotes found", "err", err)
-		format.DisplayInteractionError(session, intr, "No quotes found.")
+	selectedQuote, err := quote.DrawQuote(ctx, c.Storage(), intr.GuildID, userID, opts)
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Info("no quotes found")
+		format.DisplayInteractionError(session, intr, i18n.T(intr, "quote.none_found"))
 		return
 	}
-
-	ind := 0
-	if len(quotes) > 1 {
-		ind = rand.Intn(len(quotes))
+	if err != nil {
+		log.Error("failure getting quotes", "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "quote.error_getting"), err)
+		return
 	}
 
-	selectedQuote := quotes[ind]
 	log = log.With(
 		slog.Group(
 			"random",
 			"selectedQuote", selectedQuote,
 		),
 	)
-	if byUser == nil {
-		byUser, err = session.User(selectedQuote.User)
-		if err != nil {
-			log.Error("failure getting user data", "err", err)
-			format.DisplayInteractionError(session, intr, "Error getting user data.")
-			return
+	// Conversations mention their speakers instead
+	var mention string
+	if len(selectedQuote.Lines) == 0 {
+		if byUser == nil {
+			byUser, err = session.User(selectedQuote.User)
+			if err != nil {
+				log.Error("failure getting user data", "err", err)
+				format.DisplayInteractionWithError(session, intr, i18n.T(intr, "quote.error_user"), err)
+				return
+			}
 		}
+		mention = byUser.Mention()
+	}
+
+	votes, err := quote.GetVotes(ctx, c.Storage(), selectedQuote.ID)
+	if err != nil {
+		log.Error("failure getting votes", "err", err)
 	}
-	mention := byUser.Mention()
-	dateStamp := format.TimeToTimestamp(selectedQuote.Date.UTC())
 
-	const MessageFlagsSilent = 1 << 12
 	err = session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Content: fmt.Sprintf("Here is a random quote!\n\n%s: %s\n> %s\n\n", mention, dateStamp, selectedQuote.Text),
-			Flags:   MessageFlagsSilent,
+			Content:    i18n.T(intr, "quote.random") + "\n\n" + formatQuote(i18n.Chain(intr), selectedQuote, mention, c.attachmentURLs(session, selectedQuote)),
+			Flags:      c.quoteFlags(ctx, intr.GuildID),
+			Components: voteButtons(selectedQuote.ID, votes),
 		},
 	})
 	if err != nil {
@@ -118,3 +369,67 @@ func (c *Command) randomQuote(session *discordgo.Session, intr *discordgo.Intera
 
 	log.Info("successfully quoted")
 }
+
+// attachmentURLs looks up the current URLs of the attachments of q on the message it was saved from.
+// None are returned if the message can't be found, the jump link of the quote leads to it anyway.
+func (c *Command) attachmentURLs(session *discordgo.Session, q quote.Quote) []string {
+	if len(q.Attachments) == 0 || len(q.MessageID) == 0 {
+		return nil
+	}
+
+	msg, err := session.ChannelMessage(q.ChannelID, q.MessageID)
+	if err != nil {
+		c.logger.Warn("failure getting the message of a quote", "quoteId", q.ID, "messageId", q.MessageID, "err", err)
+		return nil
+	}
+
+	urls := []string{}
+	for _, id := range q.Attachments {
+		id = quote.AttachmentID(id)
+		for _, a := range msg.Attachments {
+			if a.ID == id {
+				urls = append(urls, a.URL)
+			}
+		}
+	}
+	return urls
+}
+
+// messageFlagsSilent sends messages without notifying the users mentioned in them.
+const messageFlagsSilent discordgo.MessageFlags = 1 << 12
+
+// quoteFlags returns the flags of messages showing quotes: silent, and ephemeral if the guild wants it.
+func (c *Command) quoteFlags(ctx context.Context, guildID string) discordgo.MessageFlags {
+	flags := messageFlagsSilent
+	if settings.QuoteEphemeral.Get(ctx, c.Storage(), guildID) {
+		flags |= discordgo.MessageFlagsEphemeral
+	}
+
+	return flags
+}
+
+// formatQuote renders q as a block quote under its number, author and date, followed by
+// the urls of its attachments and the link to the message it was saved from. Conversations
+// are rendered as a dialogue under their speakers instead of mention.
+func formatQuote(chain []discordgo.Locale, q quote.Quote, mention string, urls []string) string {
+	var sb strings.Builder
+	if len(q.Lines) > 0 {
+		fmt.Fprintf(&sb, "`#%d` %s: %s\n", q.ID, speakers(q.Lines), format.TimeToTimestamp(q.Date.UTC()))
+		for _, l := range q.Lines {
+			sb.WriteString("> " + speaker(l) + ": " + strings.ReplaceAll(l.Text, "\n", "\n> ") + "\n")
+		}
+	} else {
+		fmt.Fprintf(&sb, "`#%d` %s: %s\n", q.ID, mention, format.TimeToTimestamp(q.Date.UTC()))
+		if len(q.Text) > 0 {
+			sb.WriteString("> " + strings.ReplaceAll(q.Text, "\n", "\n> ") + "\n")
+		}
+	}
+	for _, url := range urls {
+		sb.WriteString(url + "\n")
+	}
+	if link := q.Link(); len(link) > 0 {
+		sb.WriteString(i18n.Format(chain, "quote.jump", link) + "\n")
+	}
+
+	return sb.String()
+}
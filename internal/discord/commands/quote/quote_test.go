@@ -0,0 +1,54 @@
+package quote
+
+import (
+	"context"
+	"testing"
+
+	"github.com/LeBulldoge/gungus/internal/permission"
+	"github.com/LeBulldoge/gungus/internal/quote"
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestCanChange(t *testing.T) {
+	const guildID = "guild"
+
+	rules := []permission.Rule{
+		{GuildID: guildID, Command: "quote", TargetID: "regular", TargetType: permission.TargetRole, Allow: true},
+		{GuildID: guildID, Command: "quote delete", TargetID: "janitor", TargetType: permission.TargetRole, Allow: true},
+	}
+
+	q := quote.Quote{
+		ID:          1,
+		User:        "quoted",
+		SubmittedBy: "submitter",
+		Lines:       []quote.Line{{User: "quoted", Text: "hi"}, {Name: "guest", Text: "hey"}, {User: "replier", Text: "hello"}},
+	}
+
+	member := func(id string, roles ...string) *discordgo.Member {
+		return &discordgo.Member{User: &discordgo.User{ID: id}, Roles: roles}
+	}
+
+	tests := []struct {
+		name   string
+		member *discordgo.Member
+		want   bool
+	}{
+		{"submitter", member("submitter"), true},
+		{"quoted user", member("quoted"), true},
+		{"other speaker", member("replier"), true},
+		{"parent allow", member("a", "regular"), false},
+		{"exact allow", member("a", "janitor"), true},
+		{"moderator", &discordgo.Member{User: &discordgo.User{ID: "a"}, Permissions: discordgo.PermissionManageMessages}, true},
+	}
+
+	const path = "quote delete"
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, matched := permission.Evaluate(rules, guildID, path, tt.member)
+			ctx := permission.WithDecision(context.Background(), d, matched == path)
+			if got := canChange(ctx, tt.member, q); got != tt.want {
+				t.Errorf("wrong rights received. got %v, expected, %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package quote
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/LeBulldoge/gungus/internal/discord/embed"
+	"github.com/LeBulldoge/gungus/internal/discord/format"
+	"github.com/LeBulldoge/gungus/internal/discord/i18n"
+	"github.com/LeBulldoge/gungus/internal/discord/router"
+	"github.com/LeBulldoge/gungus/internal/quote"
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	searchNamespace = "quotesearch"
+	searchPageSize  = 5
+	// searchTextLength keeps the search text within the length limit of custom IDs,
+	// where it is kept for the page buttons
+	searchTextLength = 50
+	// the length limits of autocomplete choice names and embed descriptions
+	choiceLength      = 100
+	descriptionLength = 4096
+)
+
+func (c *Command) searchQuotes(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	opt := intr.ApplicationCommandData().Options[0]
+
+	var text, userID string
+	for _, o := range opt.Options {
+		switch o.Name {
+		case "text":
+			text = o.StringValue()
+		case "by_user":
+			userID = o.UserValue(nil).ID
+		}
+	}
+	text = strings.Join(quote.SearchTerms(text), " ")
+
+	data, err := c.searchPage(ctx, intr, text, userID, 0)
+	if err != nil {
+		c.logger.Error("failure searching quotes", "text", text, "byUser", userID, "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "quote.error_getting"), err)
+		return
+	}
+
+	err = session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: data,
+	})
+	if err != nil {
+		c.logger.Error("error responding to interaction", "err", err)
+	}
+}
+
+func (c *Command) searchPaginate(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	customID := router.ParseCustomID(intr.MessageComponentData().CustomID)
+	page, err := strconv.Atoi(customID.Arg(0))
+	if err != nil {
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "quote.error_getting"), fmt.Errorf("failure reading the page of a search: %w", err))
+		return
+	}
+	userID := customID.Arg(1)
+	text := customID.Arg(2)
+
+	data, err := c.searchPage(ctx, intr, text, userID, page)
+	if err != nil {
+		c.logger.Error("failure searching quotes", "text", text, "byUser", userID, "page", page, "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "quote.error_getting"), err)
+		return
+	}
+
+	err = session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: data,
+	})
+	if err != nil {
+		c.logger.Error("error responding to interaction", "err", err)
+	}
+}
+
+// searchPage renders a page of the quotes matching text, with buttons to the
+// previous and next pages.
+func (c *Command) searchPage(ctx context.Context, intr *discordgo.InteractionCreate, text string, userID string, page int) (*discordgo.InteractionResponseData, error) {
+	results, total, err := quote.Search(ctx, c.Storage(), intr.GuildID, text, userID, searchPageSize, page*searchPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &discordgo.InteractionResponseData{
+		Flags:           discordgo.MessageFlagsEphemeral,
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	}
+	if total == 0 {
+		data.Content = i18n.T(intr, "quote.search_none", text)
+		return data, nil
+	}
+
+	var sb strings.Builder
+	for _, r := range results {
+		q := r.Quote
+		// Conversations are shown as a dialogue, without their matches highlighted
+		if len(q.Lines) == 0 {
+			q.Text = r.Highlighted
+		}
+		// Lists only link to the messages quotes were saved from, instead of looking up their attachments
+		sb.WriteString(formatQuote(i18n.Chain(intr), q, "<@"+q.User+">", nil))
+		sb.WriteRune('\n')
+	}
+
+	pages := (total + searchPageSize - 1) / searchPageSize
+	builder := embed.NewEmbed().
+		SetTitle(i18n.T(intr, "quote.search_title", text)).
+		SetDescription(truncate(sb.String(), descriptionLength)).
+		SetFooter(i18n.T(intr, "quote.search_footer", page+1, pages, total), "")
+	data.Embeds = []*discordgo.MessageEmbed{builder.MessageEmbed}
+
+	if pages > 1 {
+		data.Components = []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						CustomID: router.NewCustomID(searchNamespace, strconv.Itoa(page-1), userID, text).String(),
+						Emoji: &discordgo.ComponentEmoji{
+							Name: "⬅️",
+						},
+						Style:    discordgo.SecondaryButton,
+						Disabled: page == 0,
+					},
+					discordgo.Button{
+						CustomID: router.NewCustomID(searchNamespace, strconv.Itoa(page+1), userID, text).String(),
+						Emoji: &discordgo.ComponentEmoji{
+							Name: "➡️",
+						},
+						Style:    discordgo.SecondaryButton,
+						Disabled: page >= pages-1,
+					},
+				},
+			},
+		}
+	}
+
+	return data, nil
+}
+
+func (c *Command) searchAutocomplete(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	opt := intr.ApplicationCommandData().Options[0]
+
+	var text, userID string
+	for _, o := range opt.Options {
+		switch o.Name {
+		case "text":
+			text = o.StringValue()
+		case "by_user":
+			userID = o.UserValue(nil).ID
+		}
+	}
+
+	results, _, err := quote.Search(ctx, c.Storage(), intr.GuildID, text, userID, 25, 0)
+	if err != nil {
+		c.logger.Error("failure searching quotes", "text", text, "err", err)
+	}
+
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	for _, r := range results {
+		value := truncate(strings.Join(quote.SearchTerms(r.Text), " "), searchTextLength)
+		if len(value) == 0 {
+			continue
+		}
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  truncate(fmt.Sprintf("#%d: %s", r.ID, strings.Join(strings.Fields(r.Text), " ")), choiceLength),
+			Value: value,
+		})
+	}
+
+	err = session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+	if err != nil {
+		c.logger.Error("failure responding to autocomplete", "err", err)
+	}
+}
+
+func truncate(s string, length int) string {
+	if utf8.RuneCountInString(s) <= length {
+		return s
+	}
+	return string([]rune(s)[:length-1]) + "…"
+}
@@ -0,0 +1,140 @@
+package quote
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/LeBulldoge/gungus/internal/discord/embed"
+	"github.com/LeBulldoge/gungus/internal/discord/format"
+	"github.com/LeBulldoge/gungus/internal/discord/i18n"
+	"github.com/LeBulldoge/gungus/internal/quote"
+	"github.com/bwmarrin/discordgo"
+)
+
+// topLength is how many quotes and members the top and the leaderboard show.
+const topLength = 10
+
+// periods are the choices of the period of /quote top, with how far back they go.
+var periods = []struct {
+	name        string
+	years       int
+	months      int
+	days        int
+	description string
+}{
+	{"day", 0, 0, 1, "Past day"},
+	{"week", 0, 0, 7, "Past week"},
+	{"month", 0, 1, 0, "Past month"},
+	{"year", 1, 0, 0, "Past year"},
+}
+
+func periodChoices() []*discordgo.ApplicationCommandOptionChoice {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(periods))
+	for _, p := range periods {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  p.description,
+			Value: p.name,
+		})
+	}
+	return choices
+}
+
+// periodStart returns when the period named name started at now, or the zero time for all time.
+func periodStart(name string, now time.Time) time.Time {
+	for _, p := range periods {
+		if p.name == name {
+			return now.AddDate(-p.years, -p.months, -p.days)
+		}
+	}
+	return time.Time{}
+}
+
+func (c *Command) topQuotes(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	opt := intr.ApplicationCommandData().Options[0]
+
+	var userID, period string
+	for _, o := range opt.Options {
+		switch o.Name {
+		case "by_user":
+			userID = o.UserValue(nil).ID
+		case "period":
+			period = o.StringValue()
+		}
+	}
+
+	log := c.logger.With(slog.Group("top", "byUser", userID, "period", period))
+
+	quotes, err := quote.GetTopQuotes(ctx, c.Storage(), intr.GuildID, userID, periodStart(period, time.Now()), topLength)
+	if err != nil {
+		log.Error("failure getting top quotes", "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "quote.error_getting"), err)
+		return
+	}
+	if len(quotes) == 0 {
+		format.DisplayInteractionError(session, intr, i18n.T(intr, "quote.top_none"))
+		return
+	}
+
+	var sb strings.Builder
+	for _, q := range quotes {
+		fmt.Fprintf(&sb, "**%+d** ", q.Score)
+		// Lists only link to the messages quotes were saved from, instead of looking up their attachments
+		sb.WriteString(formatQuote(i18n.Chain(intr), q, "<@"+q.User+">", nil))
+		sb.WriteRune('\n')
+	}
+
+	title := i18n.T(intr, "quote.top_title")
+	if len(period) > 0 {
+		title = i18n.T(intr, "quote.top_title_"+period)
+	}
+	builder := embed.NewEmbed().
+		SetTitle(title).
+		SetDescription(truncate(sb.String(), descriptionLength))
+
+	c.respondTop(ctx, session, intr, builder.MessageEmbed, log)
+}
+
+func (c *Command) leaderboard(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	log := c.logger.With(slog.Group("leaderboard", "guildId", intr.GuildID))
+
+	quoted, err := quote.GetLeaderboard(ctx, c.Storage(), intr.GuildID, topLength)
+	if err != nil {
+		log.Error("failure getting the quote leaderboard", "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "quote.error_getting"), err)
+		return
+	}
+	if len(quoted) == 0 {
+		format.DisplayInteractionError(session, intr, i18n.T(intr, "quote.none_found"))
+		return
+	}
+
+	var sb strings.Builder
+	for i, q := range quoted {
+		sb.WriteString(i18n.T(intr, "quote.leaderboard_entry", i+1, "<@"+q.User+">", q.Quotes, q.Score))
+		sb.WriteRune('\n')
+	}
+
+	builder := embed.NewEmbed().
+		SetTitle(i18n.T(intr, "quote.leaderboard_title")).
+		SetDescription(sb.String())
+
+	c.respondTop(ctx, session, intr, builder.MessageEmbed, log)
+}
+
+// respondTop shows e without notifying the members mentioned in it.
+func (c *Command) respondTop(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate, e *discordgo.MessageEmbed, log *slog.Logger) {
+	err := session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:          []*discordgo.MessageEmbed{e},
+			Flags:           c.quoteFlags(ctx, intr.GuildID),
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		},
+	})
+	if err != nil {
+		log.Error("error responding to interaction", "err", err)
+	}
+}
@@ -0,0 +1,113 @@
+package quote
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/LeBulldoge/gungus/internal/audit"
+	"github.com/LeBulldoge/gungus/internal/discord/format"
+	"github.com/LeBulldoge/gungus/internal/discord/i18n"
+	"github.com/LeBulldoge/gungus/internal/discord/router"
+	"github.com/LeBulldoge/gungus/internal/quote"
+	"github.com/bwmarrin/discordgo"
+)
+
+const voteNamespace = "quotevote"
+
+// voteButtons are the buttons to upvote and downvote the quote id, showing its votes.
+func voteButtons(id int64, votes quote.Votes) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					CustomID: router.NewCustomID(voteNamespace, strconv.FormatInt(id, 10), "1").String(),
+					Label:    strconv.Itoa(votes.Up),
+					Emoji: &discordgo.ComponentEmoji{
+						Name: "👍",
+					},
+					Style: discordgo.SecondaryButton,
+				},
+				discordgo.Button{
+					CustomID: router.NewCustomID(voteNamespace, strconv.FormatInt(id, 10), "-1").String(),
+					Label:    strconv.Itoa(votes.Down),
+					Emoji: &discordgo.ComponentEmoji{
+						Name: "👎",
+					},
+					Style: discordgo.SecondaryButton,
+				},
+			},
+		},
+	}
+}
+
+// speaks reports whether user is quoted in q.
+func speaks(q quote.Quote, user string) bool {
+	if q.User == user {
+		return true
+	}
+	for _, l := range q.Lines {
+		if l.User == user {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Command) voteQuote(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	customID := router.ParseCustomID(intr.MessageComponentData().CustomID)
+	id, err := strconv.ParseInt(customID.Arg(0), 10, 64)
+	if err != nil {
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "quote.error_voting"), fmt.Errorf("failure reading the quote of a vote: %w", err))
+		return
+	}
+	value, err := strconv.Atoi(customID.Arg(1))
+	if err != nil || (value != 1 && value != -1) {
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "quote.error_voting"), fmt.Errorf("invalid vote %q", customID.Arg(1)))
+		return
+	}
+
+	voter := intr.Member.User.ID
+	log := c.logger.With(slog.Group("vote", "quoteId", id, "voter", voter, "value", value))
+
+	q, ok := c.findQuote(ctx, session, intr, id, log)
+	if !ok {
+		return
+	}
+	if speaks(q, voter) {
+		format.DisplayInteractionError(session, intr, i18n.T(intr, "quote.vote_own"))
+		return
+	}
+
+	votes, err := quote.Vote(ctx, c.Storage(), intr.GuildID, id, voter, value)
+	if errors.Is(err, sql.ErrNoRows) {
+		format.DisplayInteractionError(session, intr, i18n.T(intr, "quote.not_found", id))
+		return
+	}
+	if err != nil {
+		log.Error("failure voting on quote", "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "quote.error_voting"), err)
+		return
+	}
+	if err := audit.Record(ctx, c.Storage(), intr, "quote vote", audit.Payload{"quoteId": id, "value": value}); err != nil {
+		log.Error("failure recording audit entry", "err", err)
+	}
+
+	err = session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    intr.Message.Content,
+			Embeds:     intr.Message.Embeds,
+			Components: voteButtons(id, votes),
+		},
+	})
+	if err != nil {
+		log.Error("error responding to interaction", "err", err)
+		return
+	}
+
+	log.Info("voted on quote", "score", votes.Score())
+}
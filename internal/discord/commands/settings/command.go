@@ -0,0 +1,95 @@
+package settings
+
+import (
+	"log/slog"
+
+	"github.com/LeBulldoge/gungus/internal/database"
+	"github.com/LeBulldoge/gungus/internal/discord/bot"
+	"github.com/LeBulldoge/gungus/internal/discord/help"
+	"github.com/bwmarrin/discordgo"
+)
+
+type Command struct {
+	database.WithStorage
+
+	logger *slog.Logger
+}
+
+func NewCommand() *Command {
+	return &Command{}
+}
+
+var manageGuild int64 = discordgo.PermissionManageGuild
+
+func (c *Command) GetSignature() []*discordgo.ApplicationCommand {
+	keyOption := &discordgo.ApplicationCommandOption{
+		Name:         "key",
+		Description:  "Setting to change",
+		Type:         discordgo.ApplicationCommandOptionString,
+		Required:     true,
+		Autocomplete: true,
+	}
+
+	return []*discordgo.ApplicationCommand{
+		{
+			Name:                     "settings",
+			Description:              "Configure the bot for this server",
+			DefaultMemberPermissions: &manageGuild,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Name:        "list",
+					Description: "Show the settings of this server",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+				},
+				{
+					Name:        "set",
+					Description: "Change a setting",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options: []*discordgo.ApplicationCommandOption{
+						keyOption,
+						{
+							Name:        "value",
+							Description: "New value",
+							Type:        discordgo.ApplicationCommandOptionString,
+							Required:    true,
+						},
+					},
+				},
+				{
+					Name:        "reset",
+					Description: "Restore the default of a setting",
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Options:     []*discordgo.ApplicationCommandOption{keyOption},
+				},
+			},
+		},
+	}
+}
+
+func (c *Command) GetExamples() []help.Example {
+	return []help.Example{
+		{Command: "settings set", Usage: "/settings set key:play.idle_timeout value:5m", Description: "settings.examples.set"},
+	}
+}
+
+func (c *Command) AddLogger(logger *slog.Logger) {
+	if logger == nil {
+		return
+	}
+
+	c.logger = logger
+}
+
+func (c *Command) Setup(bot *bot.Bot) error {
+	bot.Router.HandleCommand("settings list", c.listSettings)
+	bot.Router.HandleCommand("settings set", c.setSetting)
+	bot.Router.HandleAutocomplete("settings set", "key", c.keyAutocomplete)
+	bot.Router.HandleCommand("settings reset", c.resetSetting)
+	bot.Router.HandleAutocomplete("settings reset", "key", c.keyAutocomplete)
+
+	return nil
+}
+
+func (c *Command) Cleanup(bot *bot.Bot) error {
+	return nil
+}
@@ -0,0 +1,145 @@
+package settings
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/LeBulldoge/gungus/internal/audit"
+	"github.com/LeBulldoge/gungus/internal/discord/embed"
+	"github.com/LeBulldoge/gungus/internal/discord/format"
+	"github.com/LeBulldoge/gungus/internal/discord/i18n"
+	"github.com/LeBulldoge/gungus/internal/discord/ops"
+	"github.com/LeBulldoge/gungus/internal/permission"
+	"github.com/LeBulldoge/gungus/internal/settings"
+	"github.com/bwmarrin/discordgo"
+)
+
+func (c *Command) listSettings(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	values, err := c.Storage().GuildSettings(ctx, intr.GuildID)
+	if err != nil {
+		c.logger.Error("failure getting settings", "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "settings.error_getting"), err)
+		return
+	}
+
+	builder := embed.NewEmbed().SetTitle(i18n.T(intr, "settings.title"))
+	for _, def := range settings.All {
+		value, ok := values[def.Key()]
+		if !ok {
+			value = i18n.T(intr, "settings.default", def.DefaultString())
+		}
+		builder.AddField(def.Key(), fmt.Sprintf("%s\n`%s`", i18n.T(intr, def.Description()), value))
+	}
+
+	err = session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{builder.MessageEmbed},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		c.logger.Error("failure responding to interaction", "err", err)
+	}
+}
+
+func (c *Command) setSetting(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	if !permission.CanManage(ctx, intr.Member) {
+		format.DisplayInteractionError(session, intr, i18n.T(intr, "settings.manage_only"))
+		return
+	}
+
+	opt := intr.ApplicationCommandData().Options[0]
+	key := opt.Options[0].StringValue()
+	value := opt.Options[1].StringValue()
+
+	log := c.logger.With("key", key, "value", value)
+
+	if key == settings.OpsChannel.Key() {
+		if id, err := settings.OpsChannel.Normalize(value); err == nil {
+			ok, err := ops.InGuild(session, id, intr.GuildID)
+			if err != nil {
+				log.Error("failure getting channel", "err", err)
+				format.DisplayInteractionWithError(session, intr, i18n.T(intr, "settings.error_setting"), err)
+				return
+			}
+			if !ok {
+				format.DisplayInteractionError(session, intr, i18n.T(intr, "settings.other_guild"))
+				return
+			}
+		}
+	}
+
+	value, err := settings.Set(ctx, c.Storage(), intr.GuildID, key, value)
+	if err != nil {
+		log.Error("failure setting value", "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "settings.error_setting"), err)
+		return
+	}
+	if err := audit.Record(ctx, c.Storage(), intr, "settings set", audit.Payload{"key": key, "value": value}); err != nil {
+		log.Error("failure recording audit entry", "err", err)
+	}
+
+	c.respond(session, intr, i18n.T(intr, "settings.set", key, value))
+
+	log.Info("setting changed")
+}
+
+func (c *Command) resetSetting(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	if !permission.CanManage(ctx, intr.Member) {
+		format.DisplayInteractionError(session, intr, i18n.T(intr, "settings.manage_only"))
+		return
+	}
+
+	key := intr.ApplicationCommandData().Options[0].Options[0].StringValue()
+
+	err := settings.Reset(ctx, c.Storage(), intr.GuildID, key)
+	if err != nil {
+		c.logger.Error("failure resetting setting", "key", key, "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "settings.error_resetting"), err)
+		return
+	}
+	if err := audit.Record(ctx, c.Storage(), intr, "settings reset", audit.Payload{"key": key}); err != nil {
+		c.logger.Error("failure recording audit entry", "err", err)
+	}
+
+	c.respond(session, intr, i18n.T(intr, "settings.reset", key))
+}
+
+func (c *Command) respond(session *discordgo.Session, intr *discordgo.InteractionCreate, content string) {
+	err := session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		c.logger.Error("failure responding to interaction", "err", err)
+	}
+}
+
+func (c *Command) keyAutocomplete(_ context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	query := strings.ToLower(intr.ApplicationCommandData().Options[0].Options[0].StringValue())
+
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	for _, def := range settings.All {
+		if strings.Contains(def.Key(), query) {
+			choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+				Name:  fmt.Sprintf("%s: %s", def.Key(), i18n.T(intr, def.Description())),
+				Value: def.Key(),
+			})
+		}
+	}
+
+	err := session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+	if err != nil {
+		c.logger.Error("failure responding to autocomplete", "err", err)
+	}
+}
@@ -0,0 +1,57 @@
+package status
+
+import (
+	"log/slog"
+
+	"github.com/LeBulldoge/gungus/internal/database"
+	"github.com/LeBulldoge/gungus/internal/discord/bot"
+	"github.com/bwmarrin/discordgo"
+)
+
+// PlayerCounter reports how many audio players are running.
+type PlayerCounter interface {
+	ActivePlayers() int
+}
+
+type Command struct {
+	database.WithStorage
+
+	players PlayerCounter
+	info    bot.Info
+
+	logger *slog.Logger
+}
+
+func NewCommand(players PlayerCounter) *Command {
+	return &Command{players: players}
+}
+
+func (c *Command) GetSignature() []*discordgo.ApplicationCommand {
+	return []*discordgo.ApplicationCommand{
+		{
+			Name:        "status",
+			Description: "Show the version, uptime and dependencies of the bot",
+			Type:        discordgo.ChatApplicationCommand,
+		},
+	}
+}
+
+func (c *Command) AddLogger(logger *slog.Logger) {
+	if logger == nil {
+		return
+	}
+
+	c.logger = logger
+}
+
+func (c *Command) Setup(bot *bot.Bot) error {
+	c.info = bot.Info
+
+	bot.Router.HandleCommand("status", c.handleStatus)
+
+	return nil
+}
+
+func (c *Command) Cleanup(bot *bot.Bot) error {
+	return nil
+}
@@ -0,0 +1,62 @@
+package status
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/LeBulldoge/gungus/internal/discord/embed"
+	"github.com/LeBulldoge/gungus/internal/discord/format"
+	"github.com/LeBulldoge/gungus/internal/discord/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+func (c *Command) handleStatus(ctx context.Context, session *discordgo.Session, intr *discordgo.InteractionCreate) {
+	schemaVersion := i18n.T(intr, "status.unavailable")
+	if v, err := c.Storage().SchemaVersion(ctx); err != nil {
+		c.logger.Error("failure getting schema version", "err", err)
+	} else {
+		schemaVersion = strconv.Itoa(v)
+	}
+
+	session.State.RLock()
+	guildCount := len(session.State.Guilds)
+	session.State.RUnlock()
+
+	builder := embed.NewEmbed().
+		SetTitle(i18n.T(intr, "status.title")).
+		AddInlineField(i18n.T(intr, "status.version"), valueOrUnknown(intr, c.info.Version)).
+		AddInlineField(i18n.T(intr, "status.build"), valueOrUnknown(intr, c.info.Build)).
+		AddInlineField(i18n.T(intr, "status.uptime"), time.Since(c.info.StartedAt).Round(time.Second).String()).
+		AddInlineField(i18n.T(intr, "status.guilds"), strconv.Itoa(guildCount)).
+		AddInlineField(i18n.T(intr, "status.active_players"), strconv.Itoa(c.players.ActivePlayers())).
+		AddInlineField(i18n.T(intr, "status.schema_version"), schemaVersion)
+
+	for _, tool := range slices.Sorted(maps.Keys(c.info.Tools)) {
+		builder.AddInlineField(tool, c.info.Tools[tool])
+	}
+
+	builder.SetFooter(i18n.T(intr, "status.started_at", c.info.StartedAt.Format(time.RFC1123)), "")
+
+	err := session.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{builder.MessageEmbed},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		c.logger.Error("failure responding to interaction", "err", err)
+		format.DisplayInteractionWithError(session, intr, i18n.T(intr, "status.error_displaying"), err)
+	}
+}
+
+// Embed fields can't be empty, which version and build are in development builds.
+func valueOrUnknown(intr *discordgo.InteractionCreate, s string) string {
+	if len(s) == 0 {
+		return i18n.T(intr, "status.unknown")
+	}
+	return s
+}
@@ -1,19 +1,23 @@
 package discord
 
 import (
+	"context"
 	"log/slog"
 
+	"github.com/LeBulldoge/gungus/internal/config"
 	"github.com/LeBulldoge/gungus/internal/database"
 	"github.com/LeBulldoge/gungus/internal/discord/bot"
 	"github.com/LeBulldoge/gungus/internal/discord/commands"
+	"github.com/LeBulldoge/gungus/internal/discord/format"
 )
 
-func StartBot(token string, storage *database.Storage) (*bot.Bot, error) {
-	bot, err := bot.NewBot(token, storage)
+func StartBot(cfg config.Config, storage *database.Storage, info bot.Info) (*bot.Bot, error) {
+	bot, err := bot.NewBot(cfg, storage, info)
 	if err != nil {
 		slog.Error("error while creating session", "err", err)
 		return bot, err
 	}
+	format.AddErrorListener(bot.Ops.InteractionError)
 
 	err = bot.OpenConnection()
 	if err != nil {
@@ -30,3 +34,25 @@ func StartBot(token string, storage *database.Storage) (*bot.Bot, error) {
 
 	return bot, err
 }
+
+// StopBot shuts the bot down once commands have cleaned up and in-flight interactions
+// are handled, or when ctx is done, whichever happens first.
+func StopBot(ctx context.Context, bot *bot.Bot) {
+	slog.Info("shutting down")
+
+	bot.Stop()
+
+	if err := commands.CleanupCommands(ctx, bot); err != nil {
+		slog.Error("error while cleaning up commands", "err", err)
+	}
+
+	if err := bot.Router.Wait(ctx); err != nil {
+		slog.Error("interactions still in flight, cancelling them", "err", err)
+	}
+
+	if err := bot.Ops.Wait(ctx); err != nil {
+		slog.Error("failure reports still being posted", "err", err)
+	}
+
+	bot.Shutdown()
+}
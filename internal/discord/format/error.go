@@ -5,31 +5,67 @@ import (
 	"log/slog"
 	"strings"
 
+	"github.com/LeBulldoge/gungus/internal/discord/i18n"
 	"github.com/bwmarrin/discordgo"
 )
 
+// ErrorListener is notified about every error displayed to a user.
+// cause is nil when the error was displayed without one.
+type ErrorListener func(intr *discordgo.InteractionCreate, content string, cause error)
+
+var errorListeners []ErrorListener
+
+// AddErrorListener registers l to be called by DisplayInteractionError and
+// DisplayInteractionWithError. It must be called before the bot starts handling interactions.
+func AddErrorListener(l ErrorListener) {
+	errorListeners = append(errorListeners, l)
+}
+
 func CheckDiscordErrCode(err error, code int) bool {
 	var restErr *discordgo.RESTError
 	return errors.As(err, &restErr) && restErr.Message != nil && restErr.Message.Code == code
 }
 
+// CorrelationID identifies the error of an interaction in the logs and in the reports
+// of the ops channel.
+func CorrelationID(intr *discordgo.InteractionCreate) string {
+	return intr.ID
+}
+
+// DisplayInteractionWithError shows content and cause to the user, along with the
+// correlation ID of the interaction to quote when reporting the error.
 func DisplayInteractionWithError(s *discordgo.Session, intr *discordgo.InteractionCreate, content string, cause error) {
+	notifyErrorListeners(intr, content, cause)
+
 	errStr := cause.Error()
+	reference := i18n.T(intr, "errors.reference", CorrelationID(intr))
 
 	var sb strings.Builder
-	sb.Grow(len(content) + len(errStr) + 6)
+	sb.Grow(len(content) + len(errStr) + len(reference) + 8)
 	sb.WriteString(content)
 	sb.WriteRune('\n')
 	sb.WriteRune('\n')
 	sb.WriteRune('`')
 	sb.WriteString(errStr)
 	sb.WriteRune('`')
-	content = sb.String()
+	sb.WriteString("\n-# ")
+	sb.WriteString(reference)
 
-	DisplayInteractionError(s, intr, content)
+	respondWithError(s, intr, sb.String())
 }
 
 func DisplayInteractionError(s *discordgo.Session, intr *discordgo.InteractionCreate, content string) {
+	notifyErrorListeners(intr, content, nil)
+	respondWithError(s, intr, content)
+}
+
+func notifyErrorListeners(intr *discordgo.InteractionCreate, content string, cause error) {
+	for _, l := range errorListeners {
+		l(intr, content, cause)
+	}
+}
+
+func respondWithError(s *discordgo.Session, intr *discordgo.InteractionCreate, content string) {
 	err := s.InteractionRespond(intr.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
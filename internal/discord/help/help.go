@@ -0,0 +1,243 @@
+package help
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/LeBulldoge/gungus/internal/discord/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+// Example shows how to use a command in /help.
+type Example struct {
+	// Command is the path of the command the example belongs to, like "movie rate"
+	Command string
+	// Usage is what the user types, like "/movie rate title:Alien rating:8.5"
+	Usage string
+	// Description is the key of the message explaining the example, like "movie.examples.rate"
+	Description string
+}
+
+// Documented is implemented by commands that attach usage examples to /help.
+type Documented interface {
+	GetExamples() []Example
+}
+
+// Page is one embed of the help of a command.
+type Page struct {
+	Title       string
+	Description string
+}
+
+// pageLength keeps pages readable, well within the length limit of an embed description.
+const pageLength = 2000
+
+// Overview lists the commands with their descriptions.
+func Overview(chain []discordgo.Locale, cmds []*discordgo.ApplicationCommand) Page {
+	var sb strings.Builder
+	for _, cmd := range cmds {
+		if cmd.Type == discordgo.MessageApplicationCommand || cmd.Type == discordgo.UserApplicationCommand {
+			fmt.Fprintf(&sb, "**%s**: %s\n", localized(chain, cmd.Name, cmd.NameLocalizations), contextMenu(chain, cmd))
+			continue
+		}
+		fmt.Fprintf(&sb, "**/%s**: %s\n", cmd.Name, localized(chain, cmd.Description, cmd.DescriptionLocalizations))
+	}
+	sb.WriteRune('\n')
+	sb.WriteString(i18n.Format(chain, "help.overview_hint"))
+
+	return Page{
+		Title:       i18n.Format(chain, "help.overview"),
+		Description: sb.String(),
+	}
+}
+
+// Pages renders the subcommands, options and examples of cmd, split into pages
+// between subcommands when they get long.
+func Pages(chain []discordgo.Locale, cmd *discordgo.ApplicationCommand, examples []Example) []Page {
+	if cmd.Type == discordgo.MessageApplicationCommand || cmd.Type == discordgo.UserApplicationCommand {
+		return []Page{{
+			Title:       localized(chain, cmd.Name, cmd.NameLocalizations),
+			Description: contextMenu(chain, cmd),
+		}}
+	}
+
+	var sections []string
+	var walk func(path string, desc string, opts []*discordgo.ApplicationCommandOption)
+	walk = func(path string, desc string, opts []*discordgo.ApplicationCommandOption) {
+		var params []*discordgo.ApplicationCommandOption
+		for _, opt := range opts {
+			switch opt.Type {
+			case discordgo.ApplicationCommandOptionSubCommand, discordgo.ApplicationCommandOptionSubCommandGroup:
+				walk(path+" "+opt.Name, localized(chain, opt.Description, &opt.DescriptionLocalizations), opt.Options)
+			default:
+				params = append(params, opt)
+			}
+		}
+		if len(params) > 0 || !hasSubcommands(opts) {
+			sections = append(sections, section(chain, path, desc, params))
+		}
+	}
+	walk(cmd.Name, localized(chain, cmd.Description, cmd.DescriptionLocalizations), cmd.Options)
+
+	if usages := exampleSection(chain, cmd.Name, examples); len(usages) > 0 {
+		sections = append(sections, usages)
+	}
+
+	var pages []Page
+	var sb strings.Builder
+	for _, s := range sections {
+		if sb.Len() > 0 && utf8.RuneCountInString(sb.String())+utf8.RuneCountInString(s) > pageLength {
+			pages = append(pages, Page{Title: "/" + cmd.Name, Description: strings.TrimSpace(sb.String())})
+			sb.Reset()
+		}
+		sb.WriteString(s)
+		sb.WriteString("\n\n")
+	}
+	pages = append(pages, Page{Title: "/" + cmd.Name, Description: strings.TrimSpace(sb.String())})
+
+	return pages
+}
+
+func hasSubcommands(opts []*discordgo.ApplicationCommandOption) bool {
+	for _, opt := range opts {
+		if opt.Type == discordgo.ApplicationCommandOptionSubCommand || opt.Type == discordgo.ApplicationCommandOptionSubCommandGroup {
+			return true
+		}
+	}
+	return false
+}
+
+// section renders the usage of a command path, like "/movie rate <title> <rating>",
+// followed by its description and one line per option.
+func section(chain []discordgo.Locale, path string, desc string, params []*discordgo.ApplicationCommandOption) string {
+	var sb strings.Builder
+	sb.WriteString("**`/" + path)
+	for _, opt := range params {
+		if opt.Required {
+			sb.WriteString(" <" + opt.Name + ">")
+		} else {
+			sb.WriteString(" [" + opt.Name + "]")
+		}
+	}
+	sb.WriteString("`**\n")
+	sb.WriteString(desc)
+
+	for _, opt := range params {
+		details := []string{typeName(chain, opt.Type)}
+		if opt.Required {
+			details = append(details, i18n.Format(chain, "help.required"))
+		} else {
+			details = append(details, i18n.Format(chain, "help.optional"))
+		}
+		if r := valueRange(chain, opt); len(r) > 0 {
+			details = append(details, r)
+		}
+		if len(opt.Choices) > 0 {
+			names := make([]string, 0, len(opt.Choices))
+			for _, choice := range opt.Choices {
+				names = append(names, "`"+localized(chain, choice.Name, &choice.NameLocalizations)+"`")
+			}
+			details = append(details, i18n.Format(chain, "help.one_of", strings.Join(names, ", ")))
+		} else if opt.Autocomplete {
+			details = append(details, i18n.Format(chain, "help.autocomplete"))
+		}
+
+		fmt.Fprintf(&sb, "\n- `%s` (%s): %s", opt.Name, strings.Join(details, ", "), localized(chain, opt.Description, &opt.DescriptionLocalizations))
+	}
+
+	return sb.String()
+}
+
+func valueRange(chain []discordgo.Locale, opt *discordgo.ApplicationCommandOption) string {
+	switch opt.Type {
+	case discordgo.ApplicationCommandOptionInteger, discordgo.ApplicationCommandOptionNumber:
+		hasMax := opt.MaxValue != 0
+		switch {
+		case opt.MinValue != nil && hasMax:
+			return i18n.Format(chain, "help.range", formatNumber(*opt.MinValue), formatNumber(opt.MaxValue))
+		case opt.MinValue != nil:
+			return i18n.Format(chain, "help.at_least", formatNumber(*opt.MinValue))
+		case hasMax:
+			return i18n.Format(chain, "help.at_most", formatNumber(opt.MaxValue))
+		}
+	case discordgo.ApplicationCommandOptionString:
+		hasMax := opt.MaxLength != 0
+		switch {
+		case opt.MinLength != nil && hasMax:
+			return i18n.Format(chain, "help.length_range", *opt.MinLength, opt.MaxLength)
+		case opt.MinLength != nil:
+			return i18n.Format(chain, "help.length_at_least", *opt.MinLength)
+		case hasMax:
+			return i18n.Format(chain, "help.length_at_most", opt.MaxLength)
+		}
+	}
+
+	return ""
+}
+
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func typeName(chain []discordgo.Locale, t discordgo.ApplicationCommandOptionType) string {
+	switch t {
+	case discordgo.ApplicationCommandOptionString:
+		return i18n.Format(chain, "help.types.string")
+	case discordgo.ApplicationCommandOptionInteger:
+		return i18n.Format(chain, "help.types.integer")
+	case discordgo.ApplicationCommandOptionBoolean:
+		return i18n.Format(chain, "help.types.boolean")
+	case discordgo.ApplicationCommandOptionUser:
+		return i18n.Format(chain, "help.types.user")
+	case discordgo.ApplicationCommandOptionChannel:
+		return i18n.Format(chain, "help.types.channel")
+	case discordgo.ApplicationCommandOptionRole:
+		return i18n.Format(chain, "help.types.role")
+	case discordgo.ApplicationCommandOptionMentionable:
+		return i18n.Format(chain, "help.types.mentionable")
+	case discordgo.ApplicationCommandOptionNumber:
+		return i18n.Format(chain, "help.types.number")
+	case discordgo.ApplicationCommandOptionAttachment:
+		return i18n.Format(chain, "help.types.attachment")
+	default:
+		return t.String()
+	}
+}
+
+func exampleSection(chain []discordgo.Locale, name string, examples []Example) string {
+	var sb strings.Builder
+	for _, ex := range examples {
+		if root, _, _ := strings.Cut(ex.Command, " "); root != name {
+			continue
+		}
+		if sb.Len() == 0 {
+			sb.WriteString("**" + i18n.Format(chain, "help.examples") + "**")
+		}
+		fmt.Fprintf(&sb, "\n`%s`\n%s", ex.Usage, i18n.Format(chain, ex.Description))
+	}
+
+	return sb.String()
+}
+
+func contextMenu(chain []discordgo.Locale, cmd *discordgo.ApplicationCommand) string {
+	name := localized(chain, cmd.Name, cmd.NameLocalizations)
+	if cmd.Type == discordgo.UserApplicationCommand {
+		return i18n.Format(chain, "help.user_app", name)
+	}
+	return i18n.Format(chain, "help.message_app", name)
+}
+
+// localized returns the translation of text in the first locale of chain that has one.
+func localized(chain []discordgo.Locale, text string, translations *map[discordgo.Locale]string) string {
+	if translations == nil {
+		return text
+	}
+	for _, locale := range chain {
+		if t, ok := (*translations)[locale]; ok {
+			return t
+		}
+	}
+	return text
+}
@@ -0,0 +1,86 @@
+package help
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestPages(t *testing.T) {
+	minAmount := 1.0
+	minRating := -10.0
+	cmd := &discordgo.ApplicationCommand{
+		Name:        "movie",
+		Description: "Interact with movies",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "list",
+				Description: "Browse the movie list",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			},
+			{
+				Name:        "rate",
+				Description: "Rate a movie on the list",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{Name: "title", Description: "Title", Type: discordgo.ApplicationCommandOptionString, Required: true, Autocomplete: true},
+					{Name: "rating", Description: "Rating", Type: discordgo.ApplicationCommandOptionNumber, Required: true, MinValue: &minRating, MaxValue: 10},
+					{Name: "amount", Description: "Amount", Type: discordgo.ApplicationCommandOptionInteger, MinValue: &minAmount},
+					{
+						Name: "mode", Description: "Mode", Type: discordgo.ApplicationCommandOptionString,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{{Name: "fast", Value: "fast"}, {Name: "slow", Value: "slow"}},
+					},
+				},
+			},
+		},
+	}
+	examples := []Example{
+		{Command: "movie rate", Usage: "/movie rate title:Alien rating:8.5", Description: "movie.examples.rate"},
+		{Command: "poll start", Usage: "/poll start", Description: "poll.examples.start"},
+	}
+
+	pages := Pages(nil, cmd, examples)
+	if len(pages) != 1 {
+		t.Fatalf("wrong page count received. got %v, expected, %v", len(pages), 1)
+	}
+
+	wants := []string{
+		"**`/movie list`**\nBrowse the movie list",
+		"**`/movie rate <title> <rating> [amount] [mode]`**\nRate a movie on the list",
+		"- `title` (text, required, with suggestions): Title",
+		"- `rating` (number, required, -10 to 10): Rating",
+		"- `amount` (whole number, optional, at least 1): Amount",
+		"- `mode` (text, optional, one of `fast`, `slow`): Mode",
+		"`/movie rate title:Alien rating:8.5`",
+	}
+	for _, want := range wants {
+		if !strings.Contains(pages[0].Description, want) {
+			t.Errorf("wrong page received. got %q, expected, to contain %q", pages[0].Description, want)
+		}
+	}
+	if strings.Contains(pages[0].Description, "/poll start") {
+		t.Errorf("wrong page received. got %q, expected, no examples of other commands", pages[0].Description)
+	}
+}
+
+func TestPagesSplit(t *testing.T) {
+	cmd := &discordgo.ApplicationCommand{Name: "long", Description: "Long"}
+	for i := 0; i < 30; i++ {
+		cmd.Options = append(cmd.Options, &discordgo.ApplicationCommandOption{
+			Name:        "sub" + strings.Repeat("x", i),
+			Description: strings.Repeat("a", 200),
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+		})
+	}
+
+	pages := Pages(nil, cmd, nil)
+	if len(pages) < 2 {
+		t.Fatalf("wrong page count received. got %v, expected, more than %v", len(pages), 1)
+	}
+	for _, page := range pages {
+		if len([]rune(page.Description)) > pageLength {
+			t.Errorf("wrong page length received. got %v, expected, at most %v", len([]rune(page.Description)), pageLength)
+		}
+	}
+}
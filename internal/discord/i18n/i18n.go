@@ -0,0 +1,234 @@
+package i18n
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/bwmarrin/discordgo"
+)
+
+// Default is the locale of the command signatures in the code, and the last
+// locale messages fall back to.
+const Default = discordgo.EnglishUS
+
+// A catalog maps the keys of messages to their text, for each locale.
+// Keys are the dotted paths of the messages in the locale files, like "quote.saved".
+type catalog map[discordgo.Locale]map[string]string
+
+//go:embed locales/*.toml
+var localeFiles embed.FS
+
+var messages = mustLoad(localeFiles)
+
+func mustLoad(fsys fs.FS) catalog {
+	c, err := load(fsys)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// load reads the locale files of fsys, named after the discord locale they contain, like "ru.toml".
+func load(fsys fs.FS) (catalog, error) {
+	files, err := fs.Glob(fsys, "locales/*.toml")
+	if err != nil {
+		return nil, err
+	}
+
+	c := catalog{}
+	for _, file := range files {
+		var raw map[string]any
+		if _, err := toml.DecodeFS(fsys, file, &raw); err != nil {
+			return nil, fmt.Errorf("failure reading locale file %s: %w", file, err)
+		}
+
+		locale := discordgo.Locale(strings.TrimSuffix(path.Base(file), ".toml"))
+		if _, ok := discordgo.Locales[locale]; !ok {
+			return nil, fmt.Errorf("locale file %s isn't named after a discord locale", file)
+		}
+
+		msgs := map[string]string{}
+		if err := flatten("", raw, msgs); err != nil {
+			return nil, fmt.Errorf("failure reading locale file %s: %w", file, err)
+		}
+		c[locale] = msgs
+	}
+
+	if _, ok := c[Default]; !ok {
+		return nil, fmt.Errorf("missing locale file of the default locale %s", Default)
+	}
+
+	return c, nil
+}
+
+func flatten(prefix string, raw map[string]any, out map[string]string) error {
+	for k, v := range raw {
+		key := prefix + k
+		switch v := v.(type) {
+		case string:
+			out[key] = v
+		case map[string]any:
+			if err := flatten(key+".", v, out); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%s must be a string or a table, got %T", key, v)
+		}
+	}
+
+	return nil
+}
+
+// Locales returns the shipped locales, the default first.
+func Locales() []discordgo.Locale {
+	res := []discordgo.Locale{Default}
+	for locale := range messages {
+		if locale != Default {
+			res = append(res, locale)
+		}
+	}
+
+	return res
+}
+
+// Keys returns the keys of the messages of locale.
+func Keys(locale discordgo.Locale) []string {
+	res := make([]string, 0, len(messages[locale]))
+	for key := range messages[locale] {
+		res = append(res, key)
+	}
+
+	return res
+}
+
+// Lookup returns the message of the first locale in the chain that has key.
+// A locale without a catalog of its own falls back to one of the same language,
+// so "en-GB" uses "en-US". Default is always tried last.
+func Lookup(chain []discordgo.Locale, key string) (string, bool) {
+	for _, locale := range slices.Concat(chain, []discordgo.Locale{Default}) {
+		if msgs, ok := resolve(locale); ok {
+			if msg, ok := msgs[key]; ok {
+				return msg, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+func resolve(locale discordgo.Locale) (map[string]string, bool) {
+	if msgs, ok := messages[locale]; ok {
+		return msgs, true
+	}
+
+	lang, _, _ := strings.Cut(string(locale), "-")
+	for l, msgs := range messages {
+		if other, _, _ := strings.Cut(string(l), "-"); len(lang) > 0 && other == lang {
+			return msgs, true
+		}
+	}
+
+	return nil, false
+}
+
+// Chain returns the locales to look messages for intr up in: the one of the user,
+// then the one of the guild.
+func Chain(intr *discordgo.InteractionCreate) []discordgo.Locale {
+	chain := []discordgo.Locale{}
+	if len(intr.Locale) > 0 {
+		chain = append(chain, intr.Locale)
+	}
+	if intr.GuildLocale != nil {
+		chain = append(chain, *intr.GuildLocale)
+	}
+
+	return chain
+}
+
+// T returns the message key in the language of the user of intr,
+// formatted with args like fmt.Sprintf if there are any.
+func T(intr *discordgo.InteractionCreate, key string, args ...any) string {
+	return Format(Chain(intr), key, args...)
+}
+
+// TGuild returns the message key in the language of the guild of intr, for messages
+// sent to a channel instead of the user.
+func TGuild(intr *discordgo.InteractionCreate, key string, args ...any) string {
+	var chain []discordgo.Locale
+	if intr.GuildLocale != nil {
+		chain = append(chain, *intr.GuildLocale)
+	}
+
+	return Format(chain, key, args...)
+}
+
+// Format returns the message key in the first locale of chain that has it.
+// Unknown keys are logged and returned as they are.
+func Format(chain []discordgo.Locale, key string, args ...any) string {
+	msg, ok := Lookup(chain, key)
+	if !ok {
+		slog.Warn("unknown message key", "key", key)
+		return key
+	}
+
+	if len(args) == 0 {
+		return msg
+	}
+
+	return fmt.Sprintf(msg, args...)
+}
+
+// Localize sets the name and description localizations of the commands,
+// their options and the choices of those from the "commands" table of the catalog.
+// Its keys are command paths, like "movie rate" for a subcommand and "movie rate title"
+// for one of its options, followed by ".name" or ".description".
+// Choices are keyed by the path of their option and their name, like "features enable feature play".
+func Localize(cmds []*discordgo.ApplicationCommand) {
+	for _, cmd := range cmds {
+		if names := localizations(cmd.Name + ".name"); len(names) > 0 {
+			cmd.NameLocalizations = &names
+		}
+		if descs := localizations(cmd.Name + ".description"); len(descs) > 0 {
+			cmd.DescriptionLocalizations = &descs
+		}
+		localizeOptions(cmd.Name, cmd.Options)
+	}
+}
+
+func localizeOptions(path string, opts []*discordgo.ApplicationCommandOption) {
+	for _, opt := range opts {
+		optPath := path + " " + opt.Name
+		opt.NameLocalizations = localizations(optPath + ".name")
+		opt.DescriptionLocalizations = localizations(optPath + ".description")
+
+		for _, choice := range opt.Choices {
+			choice.NameLocalizations = localizations(optPath + " " + choice.Name + ".name")
+		}
+
+		localizeOptions(optPath, opt.Options)
+	}
+}
+
+// localizations returns the translations of a signature key in every locale but Default.
+func localizations(key string) map[discordgo.Locale]string {
+	var res map[discordgo.Locale]string
+	for locale, msgs := range messages {
+		if locale == Default {
+			continue
+		}
+		if msg, ok := msgs["commands."+key]; ok {
+			if res == nil {
+				res = map[discordgo.Locale]string{}
+			}
+			res[locale] = msg
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,61 @@
+package i18n
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+var verbRegex = regexp.MustCompile(`%[-+# 0]*[0-9]*(\.[0-9]+)?[a-zA-Z%]`)
+
+func verbs(msg string) []string {
+	return verbRegex.FindAllString(msg, -1)
+}
+
+func TestCatalogComplete(t *testing.T) {
+	for _, locale := range Locales() {
+		for key, msg := range messages[Default] {
+			got, ok := messages[locale][key]
+			if !ok {
+				t.Errorf("missing key %s in locale %s", key, locale)
+				continue
+			}
+			if !slices.Equal(verbs(got), verbs(msg)) {
+				t.Errorf("wrong verbs received for %s in locale %s. got %v, expected, %v", key, locale, verbs(got), verbs(msg))
+			}
+		}
+
+		for _, key := range Keys(locale) {
+			if _, ok := messages[Default][key]; !ok && !strings.HasPrefix(key, "commands.") {
+				t.Errorf("unknown key %s in locale %s", key, locale)
+			}
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		chain []discordgo.Locale
+		want  string
+	}{
+		{nil, messages[Default]["quote.random"]},
+		{[]discordgo.Locale{discordgo.Russian}, messages[discordgo.Russian]["quote.random"]},
+		{[]discordgo.Locale{discordgo.EnglishGB}, messages[Default]["quote.random"]},
+		{[]discordgo.Locale{discordgo.Japanese, discordgo.Russian}, messages[discordgo.Russian]["quote.random"]},
+		{[]discordgo.Locale{discordgo.Japanese}, messages[Default]["quote.random"]},
+	}
+
+	for _, tt := range tests {
+		got, ok := Lookup(tt.chain, "quote.random")
+		if !ok || got != tt.want {
+			t.Errorf("wrong message received for %v. got %q, expected, %q", tt.chain, got, tt.want)
+		}
+	}
+
+	if _, ok := Lookup(nil, "quote.missing"); ok {
+		t.Errorf("wrong result received for an unknown key. got %v, expected, %v", ok, false)
+	}
+}
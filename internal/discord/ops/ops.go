@@ -0,0 +1,221 @@
+package ops
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LeBulldoge/gungus/internal/database"
+	"github.com/LeBulldoge/gungus/internal/discord/embed"
+	"github.com/LeBulldoge/gungus/internal/discord/format"
+	"github.com/LeBulldoge/gungus/internal/discord/router"
+	"github.com/LeBulldoge/gungus/internal/settings"
+	"github.com/bwmarrin/discordgo"
+)
+
+// Report describes a failure for the operators of the bot.
+type Report struct {
+	// Title summarizes what failed, like "Command failed"
+	Title string
+	// ID correlates the report with the logs and the error shown to the user
+	ID      string
+	GuildID string
+	Command string
+	UserID  string
+	Err     error
+}
+
+// Reporter posts reports to the ops channel of the guild they happened in,
+// set with the ops.channel setting, and to the global ops channel of the bot owner.
+type Reporter struct {
+	session   *discordgo.Session
+	storage   *database.Storage
+	channelID string
+
+	posts sync.WaitGroup
+}
+
+// NewReporter creates a reporter posting to channelID, the global ops channel, if it isn't empty.
+func NewReporter(session *discordgo.Session, storage *database.Storage, channelID string) *Reporter {
+	return &Reporter{
+		session:   session,
+		storage:   storage,
+		channelID: channelID,
+	}
+}
+
+// NewCorrelationID creates an ID for a failure that isn't tied to an interaction.
+func NewCorrelationID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Report posts rep in the background.
+func (r *Reporter) Report(rep Report) {
+	r.posts.Add(1)
+	go func() {
+		defer r.posts.Done()
+		r.post(rep)
+	}()
+}
+
+// InteractionError reports errors displayed to users with a cause, see format.AddErrorListener.
+func (r *Reporter) InteractionError(intr *discordgo.InteractionCreate, content string, cause error) {
+	if cause == nil {
+		return
+	}
+
+	var userID string
+	if intr.Member != nil && intr.Member.User != nil {
+		userID = intr.Member.User.ID
+	} else if intr.User != nil {
+		userID = intr.User.ID
+	}
+
+	r.Report(Report{
+		Title:   "Command failed",
+		ID:      format.CorrelationID(intr),
+		GuildID: intr.GuildID,
+		Command: router.RouteOf(intr),
+		UserID:  userID,
+		Err:     fmt.Errorf("%s: %w", strings.TrimSuffix(content, "."), cause),
+	})
+}
+
+// Wait blocks until the reports being posted are done or ctx is.
+func (r *Reporter) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		r.posts.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *Reporter) post(rep Report) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	channels := []string{}
+	if len(r.channelID) > 0 {
+		channels = append(channels, r.channelID)
+	}
+	if len(rep.GuildID) > 0 {
+		id := settings.OpsChannel.Get(ctx, r.storage, rep.GuildID)
+		if len(id) > 0 && id != r.channelID {
+			// The setting is checked when saved, but the channel could've moved since
+			if ok, err := InGuild(r.session, id, rep.GuildID); err != nil {
+				slog.Error("failure getting the ops channel", "channelId", id, "id", rep.ID, "err", err)
+			} else if !ok {
+				slog.Warn("ops channel of another guild ignored", "channelId", id, "guildId", rep.GuildID, "id", rep.ID)
+			} else {
+				channels = append(channels, id)
+			}
+		}
+	}
+
+	for _, channelID := range channels {
+		if err := Send(r.session, channelID, rep); err != nil {
+			slog.Error("failure posting to the ops channel", "channelId", channelID, "id", rep.ID, "err", err)
+		}
+	}
+}
+
+// InGuild reports whether the channel belongs to the guild, so reports of a guild never reach another one.
+func InGuild(session *discordgo.Session, channelID string, guildID string) (bool, error) {
+	ch, err := session.State.Channel(channelID)
+	if err != nil {
+		ch, err = session.Channel(channelID)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failure getting channel %s: %w", channelID, err)
+	}
+
+	return ch.GuildID == guildID, nil
+}
+
+// Send posts rep to a channel right away. It only needs the REST API, so it works
+// before the session connects to the gateway.
+func Send(session *discordgo.Session, channelID string, rep Report) error {
+	builder := embed.NewEmbed().
+		SetTitle(rep.Title).
+		SetDescription("```\n"+errorChain(rep.Err)+"\n```").
+		SetTimestamp(time.Now().Format(time.RFC3339)).
+		SetFooter("Reference: "+rep.ID, "")
+	builder.Color = 0xe74c3c
+
+	if len(rep.Command) > 0 {
+		builder.AddInlineField("Command", "`/"+rep.Command+"`")
+	}
+	if len(rep.UserID) > 0 {
+		builder.AddInlineField("User", "<@"+rep.UserID+">")
+	}
+	if len(rep.GuildID) > 0 {
+		builder.AddInlineField("Guild", rep.GuildID)
+	}
+
+	_, err := session.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+		Embeds:          []*discordgo.MessageEmbed{builder.MessageEmbed},
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+	return err
+}
+
+// chainLength keeps the chain within the length limit of an embed description.
+const chainLength = 4000
+
+// errorChain lists the errors wrapped by err, outermost first, each without
+// the message of the error it wraps.
+func errorChain(err error) string {
+	if err == nil {
+		return "unknown error"
+	}
+
+	var lines []string
+	var walk func(err error, depth int)
+	walk = func(err error, depth int) {
+		msg := err.Error()
+		var wrapped []error
+		switch e := err.(type) {
+		case interface{ Unwrap() error }:
+			if inner := e.Unwrap(); inner != nil {
+				wrapped = []error{inner}
+				msg = strings.TrimSuffix(msg, ": "+inner.Error())
+			}
+		case interface{ Unwrap() []error }:
+			wrapped = e.Unwrap()
+			if errors.Join(wrapped...).Error() == msg {
+				msg = ""
+			}
+		}
+
+		if len(msg) > 0 {
+			lines = append(lines, strings.Repeat("  ", depth)+"↳ "+msg)
+			depth++
+		}
+		for _, inner := range wrapped {
+			walk(inner, depth)
+		}
+	}
+	walk(err, 0)
+
+	chain := strings.TrimPrefix(strings.Join(lines, "\n"), "↳ ")
+	if runes := []rune(chain); len(runes) > chainLength {
+		chain = string(runes[:chainLength]) + "…"
+	}
+
+	return chain
+}
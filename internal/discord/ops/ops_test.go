@@ -0,0 +1,34 @@
+package ops
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorChain(t *testing.T) {
+	base := errors.New("database is locked")
+
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, "unknown error"},
+		{base, "database is locked"},
+		{
+			fmt.Errorf("Error saving a quote: %w", fmt.Errorf("failure saving a quote: %w", base)),
+			"Error saving a quote\n  ↳ failure saving a quote\n    ↳ database is locked",
+		},
+		{
+			fmt.Errorf("failure cleaning up: %w", errors.Join(base, errors.New("disk full"))),
+			"failure cleaning up\n  ↳ database is locked\n  ↳ disk full",
+		},
+	}
+
+	for _, tt := range tests {
+		got := errorChain(tt.err)
+		if got != tt.want {
+			t.Errorf("wrong chain received. got %q, expected, %q", got, tt.want)
+		}
+	}
+}
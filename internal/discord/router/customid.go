@@ -0,0 +1,87 @@
+package router
+
+import (
+	"strconv"
+	"strings"
+)
+
+// CustomIDVersion is the version of the custom ID encoding written by CustomID.String.
+const CustomIDVersion = 1
+
+const (
+	customIDSeparator = ":"
+	legacySeparator   = "_"
+)
+
+var (
+	customIDEscaper   = strings.NewReplacer("%", "%25", customIDSeparator, "%3A")
+	customIDUnescaper = strings.NewReplacer("%3A", customIDSeparator, "%25", "%")
+)
+
+// CustomID is the payload of a message component or modal custom ID.
+// It is encoded as "<version>:<namespace>:<arg>:<arg>...", where the namespace
+// selects the handler and the arguments are up to it.
+type CustomID struct {
+	Version   int
+	Namespace string
+	Args      []string
+}
+
+func NewCustomID(namespace string, args ...string) CustomID {
+	return CustomID{
+		Version:   CustomIDVersion,
+		Namespace: namespace,
+		Args:      args,
+	}
+}
+
+// Arg returns the argument at i, or an empty string if there is none.
+func (id CustomID) Arg(i int) string {
+	if i < len(id.Args) {
+		return id.Args[i]
+	}
+	return ""
+}
+
+func (id CustomID) String() string {
+	var sb strings.Builder
+	sb.WriteString(strconv.Itoa(id.Version))
+	sb.WriteString(customIDSeparator)
+	sb.WriteString(customIDEscaper.Replace(id.Namespace))
+	for _, arg := range id.Args {
+		sb.WriteString(customIDSeparator)
+		sb.WriteString(customIDEscaper.Replace(arg))
+	}
+
+	return sb.String()
+}
+
+// ParseCustomID decodes a custom ID. Custom IDs created before the versioned
+// encoding, like "movielist_<id>_back", are parsed as version 0 with their
+// underscore separated parts as namespace and arguments.
+func ParseCustomID(s string) CustomID {
+	versionStr, rest, found := strings.Cut(s, customIDSeparator)
+	version, err := strconv.Atoi(versionStr)
+	if !found || err != nil || version < 1 {
+		parts := strings.Split(s, legacySeparator)
+		return CustomID{Namespace: parts[0], Args: argsOf(parts)}
+	}
+
+	parts := strings.Split(rest, customIDSeparator)
+	for i := range parts {
+		parts[i] = customIDUnescaper.Replace(parts[i])
+	}
+
+	return CustomID{
+		Version:   version,
+		Namespace: parts[0],
+		Args:      argsOf(parts),
+	}
+}
+
+func argsOf(parts []string) []string {
+	if len(parts) < 2 {
+		return nil
+	}
+	return parts[1:]
+}
@@ -0,0 +1,140 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/LeBulldoge/gungus/internal/discord/format"
+	"github.com/LeBulldoge/gungus/internal/discord/i18n"
+	"github.com/LeBulldoge/gungus/internal/metrics"
+	"github.com/bwmarrin/discordgo"
+)
+
+// Middleware wraps a handler with behaviour shared by all of them.
+type Middleware func(next Handler) Handler
+
+// Outcomes of handling an interaction.
+const (
+	OutcomeOK    = "ok"
+	OutcomeError = "error"
+	OutcomePanic = "panic"
+)
+
+type outcome struct {
+	mu    sync.Mutex
+	value string
+}
+
+func (o *outcome) set(value string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	// A panic takes precedence over errors displayed while recovering from it
+	if o.value != OutcomePanic {
+		o.value = value
+	}
+}
+
+func (o *outcome) get() string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.value
+}
+
+type outcomeKey struct{}
+
+// inflight maps interaction IDs to the outcome of their handlers,
+// so errors displayed through the format package can be attributed to them.
+var inflight sync.Map
+
+func init() {
+	format.AddErrorListener(func(intr *discordgo.InteractionCreate, _ string, _ error) {
+		if o, ok := inflight.Load(intr.ID); ok {
+			o.(*outcome).set(OutcomeError)
+		}
+	})
+}
+
+func trackOutcome(ctx context.Context, intr *discordgo.InteractionCreate) (context.Context, func()) {
+	o := &outcome{value: OutcomeOK}
+	inflight.Store(intr.ID, o)
+
+	return context.WithValue(ctx, outcomeKey{}, o), func() {
+		inflight.Delete(intr.ID)
+	}
+}
+
+// Outcome returns the outcome of the handler so far: OutcomeOK, OutcomeError if
+// an error was displayed to the user, or OutcomePanic if the handler panicked.
+func Outcome(ctx context.Context) string {
+	if o, ok := ctx.Value(outcomeKey{}).(*outcome); ok {
+		return o.get()
+	}
+	return OutcomeOK
+}
+
+// Recover responds with an error instead of crashing when a handler panics.
+func Recover() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, sesh *discordgo.Session, intr *discordgo.InteractionCreate) {
+			defer func() {
+				rec := recover()
+				if rec == nil {
+					return
+				}
+
+				if o, ok := ctx.Value(outcomeKey{}).(*outcome); ok {
+					o.set(OutcomePanic)
+				}
+
+				Logger(ctx).Error("recovered from panic in handler",
+					"route", RouteOf(intr),
+					"panic", fmt.Sprint(rec),
+					"stack", string(debug.Stack()),
+				)
+
+				if intr.Type != discordgo.InteractionApplicationCommandAutocomplete {
+					format.DisplayInteractionWithError(sesh, intr, i18n.T(intr, "errors.panic"), fmt.Errorf("panic: %v", rec))
+				}
+			}()
+
+			next(ctx, sesh, intr)
+		}
+	}
+}
+
+// LogOutcome logs the duration and outcome of every handled interaction.
+func LogOutcome() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, sesh *discordgo.Session, intr *discordgo.InteractionCreate) {
+			start := time.Now()
+
+			next(ctx, sesh, intr)
+
+			Logger(ctx).Info("interaction handled",
+				"id", format.CorrelationID(intr),
+				"route", RouteOf(intr),
+				"type", intr.Type.String(),
+				"duration", time.Since(start),
+				"outcome", Outcome(ctx),
+			)
+		}
+	}
+}
+
+// Instrument records the count, duration and outcome of handled interactions in metrics.
+func Instrument() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, sesh *discordgo.Session, intr *discordgo.InteractionCreate) {
+			start := time.Now()
+
+			next(ctx, sesh, intr)
+
+			route := RouteOf(intr)
+			metrics.HandlerDuration.WithLabelValues(route).Observe(time.Since(start).Seconds())
+			metrics.Interactions.WithLabelValues(route, Outcome(ctx)).Inc()
+		}
+	}
+}
@@ -0,0 +1,321 @@
+package router
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/LeBulldoge/gungus/internal/discord/format"
+	"github.com/LeBulldoge/gungus/internal/discord/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+type Handler func(ctx context.Context, sesh *discordgo.Session, intr *discordgo.InteractionCreate)
+
+type autocompleteKey struct {
+	path   string
+	option string
+}
+
+type route struct {
+	handler Handler
+	logger  *slog.Logger
+	feature string
+	command string
+}
+
+type routes struct {
+	mu sync.RWMutex
+
+	commands     map[string]route
+	autocomplete map[autocompleteKey]route
+	components   map[string]route
+	modals       map[string]route
+
+	middlewares []Middleware
+
+	// ctx is the parent of handler contexts, cancelled when draining times out
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	closed   bool
+	handlers sync.WaitGroup
+}
+
+// Router dispatches interactions to the handler registered for them:
+// slash commands by their path (e.g. "movie rate"), autocomplete requests by path
+// and focused option, message components and modals by their custom ID namespace.
+type Router struct {
+	*routes
+
+	logger  *slog.Logger
+	feature string
+	command string
+}
+
+func New() *Router {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Router{
+		routes: &routes{
+			commands:     make(map[string]route),
+			autocomplete: make(map[autocompleteKey]route),
+			components:   make(map[string]route),
+			modals:       make(map[string]route),
+			ctx:          ctx,
+			cancel:       cancel,
+		},
+		logger: slog.Default().WithGroup("router"),
+	}
+}
+
+// WithLogger returns a router sharing the routes of r. Handlers registered through it
+// get logger from Logger(ctx).
+func (r *Router) WithLogger(logger *slog.Logger) *Router {
+	return &Router{routes: r.routes, logger: logger, feature: r.feature, command: r.command}
+}
+
+// WithFeature returns a router sharing the routes of r. Handlers registered through it
+// belong to feature, returned by Feature(ctx).
+func (r *Router) WithFeature(feature string) *Router {
+	return &Router{routes: r.routes, logger: r.logger, feature: feature, command: r.command}
+}
+
+// WithCommand returns a router sharing the routes of r. Components and modals registered
+// through it belong to the command path, returned by Command(ctx), so they're subject
+// to the same permission rules and rate limits as the command.
+func (r *Router) WithCommand(path string) *Router {
+	return &Router{routes: r.routes, logger: r.logger, feature: r.feature, command: path}
+}
+
+// Use appends middlewares to the chain every handler is wrapped in.
+// The first middleware is the outermost one.
+func (r *Router) Use(mw ...Middleware) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.middlewares = append(r.middlewares, mw...)
+}
+
+// HandleCommand registers h for the command path, a command name followed by
+// its subcommand group and subcommand, separated by spaces. A handler registered
+// for a shorter path receives all of its subcommands without a handler of their own.
+func (r *Router) HandleCommand(path string, h Handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.commands[path] = r.route(h)
+}
+
+// HandleAutocomplete registers h for autocomplete requests of the command path
+// with option focused. An empty option matches any focused option.
+func (r *Router) HandleAutocomplete(path string, option string, h Handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.autocomplete[autocompleteKey{path, option}] = r.route(h)
+}
+
+// HandleComponent registers h for message components with a custom ID in namespace.
+func (r *Router) HandleComponent(namespace string, h Handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.components[namespace] = r.route(h)
+}
+
+// HandleModal registers h for modal submissions with a custom ID in namespace.
+func (r *Router) HandleModal(namespace string, h Handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.modals[namespace] = r.route(h)
+}
+
+func (r *Router) route(h Handler) route {
+	return route{handler: h, logger: r.logger, feature: r.feature, command: r.command}
+}
+
+// Close stops the router from dispatching new interactions,
+// which are answered with an error instead.
+func (r *Router) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+}
+
+// Wait blocks until the handlers running after Close return. If ctx is done first,
+// the contexts of the remaining handlers are cancelled and the error of ctx is returned.
+func (r *Router) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		r.handlers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		r.cancel()
+		return ctx.Err()
+	}
+}
+
+// Dispatch is the discordgo interaction handler of the router.
+func (r *Router) Dispatch(sesh *discordgo.Session, intr *discordgo.InteractionCreate) {
+	rt, middlewares, ok, closed := r.match(intr)
+	if closed {
+		r.logger.Info("rejecting interaction while shutting down", "type", intr.Type.String(), "route", RouteOf(intr))
+		if intr.Type != discordgo.InteractionApplicationCommandAutocomplete {
+			format.DisplayInteractionError(sesh, intr, i18n.T(intr, "errors.shutting_down"))
+		}
+		return
+	}
+	if !ok {
+		r.logger.Debug("no handler for interaction", "type", intr.Type.String(), "route", RouteOf(intr))
+		return
+	}
+	defer r.handlers.Done()
+
+	h := rt.handler
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+
+	ctx := context.WithValue(r.ctx, loggerKey{}, rt.logger)
+	ctx = context.WithValue(ctx, featureKey{}, rt.feature)
+	if intr.Type == discordgo.InteractionApplicationCommand {
+		ctx = context.WithValue(ctx, commandKey{}, CommandPath(intr.ApplicationCommandData()))
+	} else if intr.Type != discordgo.InteractionApplicationCommandAutocomplete {
+		ctx = context.WithValue(ctx, commandKey{}, rt.command)
+	}
+	ctx, done := trackOutcome(ctx, intr)
+	defer done()
+
+	h(ctx, sesh, intr)
+}
+
+// match finds the route of intr. Matched interactions are counted as running handlers
+// until Done is called on r.handlers.
+func (r *Router) match(intr *discordgo.InteractionCreate) (rt route, middlewares []Middleware, ok bool, closed bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.closed {
+		return route{}, nil, false, true
+	}
+
+	switch intr.Type {
+	case discordgo.InteractionApplicationCommand:
+		rt, ok = matchPath(r.commands, CommandPath(intr.ApplicationCommandData()))
+	case discordgo.InteractionApplicationCommandAutocomplete:
+		data := intr.ApplicationCommandData()
+		path := CommandPath(data)
+		rt, ok = r.autocomplete[autocompleteKey{path, FocusedOption(data)}]
+		if !ok {
+			rt, ok = r.autocomplete[autocompleteKey{path, ""}]
+		}
+	case discordgo.InteractionMessageComponent:
+		rt, ok = r.components[ParseCustomID(intr.MessageComponentData().CustomID).Namespace]
+	case discordgo.InteractionModalSubmit:
+		rt, ok = r.modals[ParseCustomID(intr.ModalSubmitData().CustomID).Namespace]
+	}
+
+	if ok {
+		r.handlers.Add(1)
+	}
+
+	return rt, r.middlewares, ok, false
+}
+
+func matchPath(handlers map[string]route, path string) (route, bool) {
+	for {
+		if rt, ok := handlers[path]; ok {
+			return rt, true
+		}
+
+		i := strings.LastIndexByte(path, ' ')
+		if i == -1 {
+			return route{}, false
+		}
+		path = path[:i]
+	}
+}
+
+type loggerKey struct{}
+
+// Logger returns the logger of the command handling the interaction.
+func Logger(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+type featureKey struct{}
+
+// Feature returns the feature the handler of the interaction belongs to, if any.
+func Feature(ctx context.Context) string {
+	feature, _ := ctx.Value(featureKey{}).(string)
+	return feature
+}
+
+type commandKey struct{}
+
+// Command returns the path of the command an interaction is for: the invoked one for
+// commands, the one owning the component or modal otherwise. It is empty for autocomplete
+// requests, and for components and modals not registered with WithCommand.
+func Command(ctx context.Context) string {
+	path, _ := ctx.Value(commandKey{}).(string)
+	return path
+}
+
+// CommandPath returns the command name followed by the invoked subcommand group
+// and subcommand, e.g. "movie rate".
+func CommandPath(data discordgo.ApplicationCommandInteractionData) string {
+	path := data.Name
+	opts := data.Options
+	for len(opts) > 0 {
+		opt := opts[0]
+		if opt.Type != discordgo.ApplicationCommandOptionSubCommandGroup && opt.Type != discordgo.ApplicationCommandOptionSubCommand {
+			break
+		}
+		path += " " + opt.Name
+		opts = opt.Options
+	}
+
+	return path
+}
+
+// FocusedOption returns the name of the option an autocomplete request is for.
+func FocusedOption(data discordgo.ApplicationCommandInteractionData) string {
+	opts := data.Options
+	for len(opts) > 0 {
+		next := opts
+		opts = nil
+		for _, opt := range next {
+			if opt.Focused {
+				return opt.Name
+			}
+			if opt.Type == discordgo.ApplicationCommandOptionSubCommandGroup || opt.Type == discordgo.ApplicationCommandOptionSubCommand {
+				opts = opt.Options
+			}
+		}
+	}
+
+	return ""
+}
+
+// RouteOf describes the route of an interaction for logging,
+// e.g. "movie rate", "movie rate:title" or "movielist".
+func RouteOf(intr *discordgo.InteractionCreate) string {
+	switch intr.Type {
+	case discordgo.InteractionApplicationCommand:
+		return CommandPath(intr.ApplicationCommandData())
+	case discordgo.InteractionApplicationCommandAutocomplete:
+		data := intr.ApplicationCommandData()
+		return CommandPath(data) + ":" + FocusedOption(data)
+	case discordgo.InteractionMessageComponent:
+		return ParseCustomID(intr.MessageComponentData().CustomID).Namespace
+	case discordgo.InteractionModalSubmit:
+		return ParseCustomID(intr.ModalSubmitData().CustomID).Namespace
+	}
+
+	return ""
+}
@@ -0,0 +1,165 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestCustomIDRoundTrip(t *testing.T) {
+	want := NewCustomID("poll", "option_0_<:gun:123>", "100%")
+
+	encoded := want.String()
+	if encoded != "1:poll:option_0_<%3Agun%3A123>:100%25" {
+		t.Fatalf("wrong encoding received. got %s", encoded)
+	}
+
+	got := ParseCustomID(encoded)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("wrong custom id received. got %+v, expected, %+v", got, want)
+	}
+}
+
+func TestParseLegacyCustomID(t *testing.T) {
+	want := CustomID{
+		Namespace: "movielist",
+		Args:      []string{"1234", "back"},
+	}
+
+	got := ParseCustomID("movielist_1234_back")
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("wrong custom id received. got %+v, expected, %+v", got, want)
+	}
+}
+
+func TestMatchPath(t *testing.T) {
+	var called string
+	handlers := map[string]route{}
+	for _, path := range []string{"movie", "movie rate"} {
+		handlers[path] = route{
+			handler: func(_ context.Context, _ *discordgo.Session, _ *discordgo.InteractionCreate) {
+				called = path
+			},
+		}
+	}
+
+	tests := map[string]string{
+		"movie rate": "movie rate",
+		"movie list": "movie",
+		"movie":      "movie",
+	}
+	for path, want := range tests {
+		called = ""
+		rt, ok := matchPath(handlers, path)
+		if !ok {
+			t.Fatalf("no handler matched for %s", path)
+		}
+		rt.handler(context.Background(), nil, nil)
+		if called != want {
+			t.Fatalf("wrong handler matched for %s. got %s, expected, %s", path, called, want)
+		}
+	}
+
+	if _, ok := matchPath(handlers, "quote add"); ok {
+		t.Fatal("expected no handler for quote add")
+	}
+}
+
+func TestDispatchRecoversPanic(t *testing.T) {
+	r := New()
+
+	var gotOutcome string
+	r.Use(func(next Handler) Handler {
+		return func(ctx context.Context, sesh *discordgo.Session, intr *discordgo.InteractionCreate) {
+			next(ctx, sesh, intr)
+			gotOutcome = Outcome(ctx)
+		}
+	}, Recover())
+
+	r.HandleComponent("boom", func(_ context.Context, _ *discordgo.Session, intr *discordgo.InteractionCreate) {
+		_ = intr.Member.User.ID
+	})
+
+	intr := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		ID:   "1",
+		Type: discordgo.InteractionMessageComponent,
+		Data: discordgo.MessageComponentInteractionData{CustomID: NewCustomID("boom").String()},
+	}}
+
+	sesh, _ := discordgo.New("")
+	sesh.Client = &http.Client{Transport: failingTransport{}}
+	sesh.MaxRestRetries = 0
+
+	r.Dispatch(sesh, intr)
+
+	if gotOutcome != OutcomePanic {
+		t.Fatalf("wrong outcome received. got %s, expected, %s", gotOutcome, OutcomePanic)
+	}
+}
+
+func TestWaitCancelsStragglers(t *testing.T) {
+	r := New()
+
+	started := make(chan struct{})
+	cancelled := make(chan struct{})
+	r.HandleComponent("slow", func(ctx context.Context, _ *discordgo.Session, _ *discordgo.InteractionCreate) {
+		close(started)
+		<-ctx.Done()
+		close(cancelled)
+	})
+
+	intr := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		ID:   "1",
+		Type: discordgo.InteractionMessageComponent,
+		Data: discordgo.MessageComponentInteractionData{CustomID: NewCustomID("slow").String()},
+	}}
+
+	go r.Dispatch(nil, intr)
+	<-started
+
+	r.Close()
+	if _, _, ok, closed := r.match(intr); ok || !closed {
+		t.Fatalf("closed router matched an interaction")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := r.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("wrong error received. got %v, expected, %v", err, context.DeadlineExceeded)
+	}
+
+	<-cancelled
+	if err := r.Wait(context.Background()); err != nil {
+		t.Fatalf("error received after handler returned. got %v", err)
+	}
+}
+
+func TestCommandOfComponent(t *testing.T) {
+	r := New()
+
+	var got string
+	r.WithCommand("movie list").HandleComponent("movielist", func(ctx context.Context, _ *discordgo.Session, _ *discordgo.InteractionCreate) {
+		got = Command(ctx)
+	})
+
+	r.Dispatch(nil, &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		ID:   "1",
+		Type: discordgo.InteractionMessageComponent,
+		Data: discordgo.MessageComponentInteractionData{CustomID: NewCustomID("movielist", "1").String()},
+	}})
+
+	if got != "movie list" {
+		t.Fatalf("wrong command received. got %q, expected, %q", got, "movie list")
+	}
+}
+
+type failingTransport struct{}
+
+func (failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("no connection")
+}
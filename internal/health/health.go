@@ -0,0 +1,81 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+const checkTimeout = 5 * time.Second
+
+// Check reports a problem with a dependency of the bot by returning an error.
+type Check func(ctx context.Context) error
+
+// Checks maps names of dependencies to their checks.
+type Checks map[string]Check
+
+// Executable checks that the executable at path can be found.
+func Executable(path string) Check {
+	return func(context.Context) error {
+		_, err := exec.LookPath(path)
+		return err
+	}
+}
+
+type report struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+const (
+	statusOK   = "ok"
+	statusFail = "fail"
+)
+
+// Handler runs all checks concurrently and responds with their results as JSON,
+// with status 503 if any of them failed.
+func Handler(checks Checks) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+		defer cancel()
+
+		rep := run(ctx, checks)
+
+		w.Header().Set("Content-Type", "application/json")
+		if rep.Status != statusOK {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(rep)
+	})
+}
+
+func run(ctx context.Context, checks Checks) report {
+	rep := report{Status: statusOK, Checks: make(map[string]string, len(checks))}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			res := statusOK
+			if err := check(ctx); err != nil {
+				res = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			rep.Checks[name] = res
+			if res != statusOK {
+				rep.Status = statusFail
+			}
+		}()
+	}
+	wg.Wait()
+
+	return rep
+}
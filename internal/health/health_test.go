@@ -0,0 +1,48 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	ok := func(context.Context) error { return nil }
+	fail := func(context.Context) error { return errors.New("unreachable") }
+
+	tests := []struct {
+		name       string
+		checks     Checks
+		wantCode   int
+		wantStatus string
+	}{
+		{"healthy", Checks{"database": ok, "gateway": ok}, http.StatusOK, statusOK},
+		{"unhealthy", Checks{"database": ok, "gateway": fail}, http.StatusServiceUnavailable, statusFail},
+		{"no checks", Checks{}, http.StatusOK, statusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			Handler(tt.checks).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+			if rec.Code != tt.wantCode {
+				t.Errorf("got code %d, expected %d", rec.Code, tt.wantCode)
+			}
+
+			var rep report
+			if err := json.NewDecoder(rec.Body).Decode(&rep); err != nil {
+				t.Fatal(err)
+			}
+			if rep.Status != tt.wantStatus {
+				t.Errorf("got status %q, expected %q", rep.Status, tt.wantStatus)
+			}
+			if len(rep.Checks) != len(tt.checks) {
+				t.Errorf("got %d check results, expected %d", len(rep.Checks), len(tt.checks))
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "gungus"
+
+var (
+	Interactions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "interactions_total",
+		Help:      "Interactions handled, by route and outcome.",
+	}, []string{"route", "outcome"})
+
+	HandlerDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "handler_duration_seconds",
+		Help:      "Time spent handling an interaction, by route.",
+		Buckets:   []float64{.01, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"route"})
+
+	ActivePlayers = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "active_players",
+		Help:      "Running audio players, by guild.",
+	}, []string{"guild"})
+
+	QueueLength = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "queue_length",
+		Help:      "Videos waiting in the playback queue, by guild.",
+	}, []string{"guild"})
+
+	ProcessFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "process_failures_total",
+		Help:      "Failed runs of external processes, by executable.",
+	}, []string{"process"})
+
+	TxDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_transaction_duration_seconds",
+		Help:      "Duration of database transactions.",
+		Buckets:   prometheus.ExponentialBuckets(.0005, 2, 14),
+	})
+
+	GatewayReconnects = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "gateway_reconnects_total",
+		Help:      "Reconnections to the discord gateway.",
+	})
+)
+
+// Process names for ProcessFailures
+const (
+	ProcessYtDlp  = "yt-dlp"
+	ProcessFFmpeg = "ffmpeg"
+)
+
+// Handler serves the metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
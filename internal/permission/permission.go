@@ -0,0 +1,197 @@
+package permission
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/LeBulldoge/gungus/internal/database"
+	"github.com/LeBulldoge/sqlighter"
+	"github.com/bwmarrin/discordgo"
+)
+
+type TargetType string
+
+const (
+	TargetRole TargetType = "role"
+	TargetUser TargetType = "user"
+)
+
+// Rule allows or denies a role or a user the use of a command path, like "movie remove".
+// A rule for a command applies to all of its subcommands without rules of their own.
+type Rule struct {
+	GuildID    string     `db:"guildId"`
+	Command    string     `db:"command"`
+	TargetID   string     `db:"targetId"`
+	TargetType TargetType `db:"targetType"`
+	Allow      bool       `db:"allow"`
+}
+
+// Mention formats the target of the rule for a message.
+func (r Rule) Mention() string {
+	switch {
+	case r.TargetType == TargetUser:
+		return "<@" + r.TargetID + ">"
+	case r.TargetID == r.GuildID:
+		return "@everyone"
+	default:
+		return "<@&" + r.TargetID + ">"
+	}
+}
+
+func GetRules(ctx context.Context, storage *database.Storage, guildID string) ([]Rule, error) {
+	res := []Rule{}
+
+	return res, storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
+		err := tx.SelectContext(ctx, &res, "SELECT * FROM Permissions WHERE guildId = ? ORDER BY command, targetType, targetId", guildID)
+		if err != nil {
+			return fmt.Errorf("failure getting permission rules: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// SetRule saves the rule, replacing the one for the same command and target.
+func SetRule(ctx context.Context, storage *database.Storage, rule Rule) error {
+	return storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO Permissions (guildId, command, targetId, targetType, allow) VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT (guildId, command, targetId) DO UPDATE SET targetType = excluded.targetType, allow = excluded.allow`,
+			rule.GuildID, rule.Command, rule.TargetID, rule.TargetType, rule.Allow,
+		)
+		if err != nil {
+			return fmt.Errorf("failure saving permission rule: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// DeleteRule removes the rule for command and target, reporting whether there was one.
+func DeleteRule(ctx context.Context, storage *database.Storage, guildID string, command string, targetID string) (bool, error) {
+	var deleted bool
+
+	return deleted, storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
+		res, err := tx.ExecContext(ctx, "DELETE FROM Permissions WHERE guildId = ? AND command = ? AND targetId = ?", guildID, command, targetID)
+		if err != nil {
+			return fmt.Errorf("failure deleting permission rule: %w", err)
+		}
+
+		n, err := res.RowsAffected()
+		deleted = n > 0
+		return err
+	})
+}
+
+type Decision int
+
+const (
+	// Default means no rule applies to the member.
+	Default Decision = iota
+	Allowed
+	Denied
+)
+
+// Evaluate decides whether member may use the command at path in the guild the rules belong to.
+// The rules of the longest matching command path apply. Among them, a rule for the member
+// comes first, then rules for their roles, where an allow beats a deny, then the rule for @everyone.
+// It also returns the command path of the rules that decided, empty for Default.
+func Evaluate(rules []Rule, guildID string, path string, member *discordgo.Member) (Decision, string) {
+	for {
+		if d := evaluatePath(rules, guildID, path, member); d != Default {
+			return d, path
+		}
+
+		i := strings.LastIndexByte(path, ' ')
+		if i == -1 {
+			return Default, ""
+		}
+		path = path[:i]
+	}
+}
+
+func evaluatePath(rules []Rule, guildID string, path string, member *discordgo.Member) Decision {
+	var user, roles, everyone Decision
+	for _, r := range rules {
+		if r.Command != path {
+			continue
+		}
+
+		d := Denied
+		if r.Allow {
+			d = Allowed
+		}
+
+		switch {
+		case r.TargetType == TargetUser && member.User != nil && r.TargetID == member.User.ID:
+			user = d
+		case r.TargetType == TargetRole && r.TargetID == guildID:
+			everyone = d
+		case r.TargetType == TargetRole && slices.Contains(member.Roles, r.TargetID):
+			if roles != Allowed {
+				roles = d
+			}
+		}
+	}
+
+	for _, d := range []Decision{user, roles, everyone} {
+		if d != Default {
+			return d
+		}
+	}
+
+	return Default
+}
+
+// IsAdmin reports whether member can manage the guild. Admins aren't subject to rules.
+func IsAdmin(member *discordgo.Member) bool {
+	return member.Permissions&(discordgo.PermissionAdministrator|discordgo.PermissionManageGuild) != 0
+}
+
+// IsModerator reports whether member can manage other members' messages.
+func IsModerator(member *discordgo.Member) bool {
+	return IsAdmin(member) || member.Permissions&discordgo.PermissionManageMessages != 0
+}
+
+type decisionKey struct{}
+
+// decision is the decision an interaction was let through with, and whether a rule
+// for the exact command path made it.
+type decision struct {
+	Decision
+	exact bool
+}
+
+// WithDecision stores the decision the interaction was let through with. exact reports
+// whether it was made by rules for the command path itself rather than a parent command.
+func WithDecision(ctx context.Context, d Decision, exact bool) context.Context {
+	return context.WithValue(ctx, decisionKey{}, decision{d, exact})
+}
+
+// FromContext returns the decision the interaction was let through with.
+func FromContext(ctx context.Context) Decision {
+	d, _ := ctx.Value(decisionKey{}).(decision)
+	return d.Decision
+}
+
+// explicitlyAllowed reports whether a rule for the exact command path handling ctx allowed it.
+// Rules inherited from parent commands only grant their use, not elevated rights.
+func explicitlyAllowed(ctx context.Context) bool {
+	d, _ := ctx.Value(decisionKey{}).(decision)
+	return d.Decision == Allowed && d.exact
+}
+
+// CanManage reports whether member may change the configuration of the bot with the command
+// handling ctx. Such commands are hidden from non-admins by default, but can be made visible
+// to others in the server settings, so a rule explicitly allowing the command path is required too.
+func CanManage(ctx context.Context, member *discordgo.Member) bool {
+	return IsAdmin(member) || explicitlyAllowed(ctx)
+}
+
+// CanModerate reports whether member may act on things created by others with the command
+// handling ctx: moderators can, as can members allowed to use its exact command path by a rule.
+func CanModerate(ctx context.Context, member *discordgo.Member) bool {
+	return IsModerator(member) || explicitlyAllowed(ctx)
+}
@@ -0,0 +1,86 @@
+package permission
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestEvaluate(t *testing.T) {
+	const guildID = "guild"
+
+	rules := []Rule{
+		{GuildID: guildID, Command: "movie", TargetID: guildID, TargetType: TargetRole, Allow: false},
+		{GuildID: guildID, Command: "movie", TargetID: "cinephile", TargetType: TargetRole, Allow: true},
+		{GuildID: guildID, Command: "movie remove", TargetID: "cinephile", TargetType: TargetRole, Allow: false},
+		{GuildID: guildID, Command: "movie remove", TargetID: "curator", TargetType: TargetRole, Allow: true},
+		{GuildID: guildID, Command: "movie remove", TargetID: "troll", TargetType: TargetUser, Allow: false},
+	}
+
+	member := func(id string, roles ...string) *discordgo.Member {
+		return &discordgo.Member{User: &discordgo.User{ID: id}, Roles: roles}
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		member   *discordgo.Member
+		want     Decision
+		wantPath string
+	}{
+		{"no rules", "play", member("a"), Default, ""},
+		{"everyone denied", "movie list", member("a"), Denied, "movie"},
+		{"role beats everyone", "movie list", member("a", "cinephile"), Allowed, "movie"},
+		{"subcommand rule", "movie remove", member("a", "cinephile"), Denied, "movie remove"},
+		{"allow beats deny among roles", "movie remove", member("a", "cinephile", "curator"), Allowed, "movie remove"},
+		{"user beats roles", "movie remove", member("troll", "curator"), Denied, "movie remove"},
+		{"falls back to parent", "movie remove", member("a"), Denied, "movie"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, path := Evaluate(rules, guildID, tt.path, tt.member)
+			if got != tt.want {
+				t.Errorf("wrong decision received. got %d, expected, %d", got, tt.want)
+			}
+			if path != tt.wantPath {
+				t.Errorf("wrong path received. got %q, expected, %q", path, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestCanModerate(t *testing.T) {
+	const guildID = "guild"
+
+	rules := []Rule{
+		{GuildID: guildID, Command: "movie", TargetID: "cinephile", TargetType: TargetRole, Allow: true},
+		{GuildID: guildID, Command: "movie remove", TargetID: "curator", TargetType: TargetRole, Allow: true},
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		member *discordgo.Member
+		want   bool
+	}{
+		{"parent allow", "movie remove", &discordgo.Member{User: &discordgo.User{ID: "a"}, Roles: []string{"cinephile"}}, false},
+		{"exact allow", "movie remove", &discordgo.Member{User: &discordgo.User{ID: "a"}, Roles: []string{"curator"}}, true},
+		{"no rule", "movie remove", &discordgo.Member{User: &discordgo.User{ID: "a"}}, false},
+		{"moderator", "movie remove", &discordgo.Member{User: &discordgo.User{ID: "a"}, Permissions: discordgo.PermissionManageMessages}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, path := Evaluate(rules, guildID, tt.path, tt.member)
+			ctx := WithDecision(context.Background(), d, path == tt.path)
+			if got := CanModerate(ctx, tt.member); got != tt.want {
+				t.Errorf("wrong moderation rights received. got %v, expected, %v", got, tt.want)
+			}
+			if got := CanManage(ctx, tt.member); got != (tt.want && tt.member.Permissions == 0) {
+				t.Errorf("wrong management rights received. got %v, expected, %v", got, tt.want && tt.member.Permissions == 0)
+			}
+		})
+	}
+}
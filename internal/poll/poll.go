@@ -10,6 +10,7 @@ type Poll struct {
 	ID      string `db:"id"`
 	Owner   string `db:"owner"`
 	Title   string `db:"title"`
+	GuildID string `db:"guild_id"`
 	Options map[string][]string
 }
 
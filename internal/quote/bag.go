@@ -0,0 +1,132 @@
+package quote
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/LeBulldoge/gungus/internal/database"
+	"github.com/LeBulldoge/sqlighter"
+)
+
+// BagOptions change how quotes are drawn from a shuffle bag.
+type BagOptions struct {
+	// Weighted draws quotes with higher scores sooner, see Weight
+	Weighted bool
+	// Recent is how many of the quotes last shown in the guild are avoided,
+	// including those drawn from other bags
+	Recent int
+}
+
+// Weight returns how likely a quote with score is drawn relative to one with a score of 0:
+// score + 1 times as likely for positive scores, 1 - score times less likely for negative ones.
+// DrawQuote computes it in SQL, see weightOrder.
+func Weight(score int) float64 {
+	if score >= 0 {
+		return float64(score + 1)
+	}
+	return 1 / float64(1-score)
+}
+
+// weightOrder orders quotes by -ln(u)/w, where u is uniformly random in (0, 1] and w is
+// the Weight of their score, so the first one is drawn with a probability proportional to its weight.
+const weightOrder = `-ln(1 - (random() & 4503599627370495) / 4503599627370496.0) /
+	(CASE WHEN score >= 0 THEN score + 1.0 ELSE 1.0 / (1 - score) END)`
+
+// DrawQuote draws a random quote of the guild, by or with user unless it's empty, from a shuffle bag
+// kept per guild and per user, so every quote is shown once before any is shown again.
+// Recent quotes are skipped unless there is nothing else left.
+// It returns sql.ErrNoRows if there is none.
+func DrawQuote(ctx context.Context, storage *database.Storage, guildID string, user string, opts BagOptions) (Quote, error) {
+	res := Quote{}
+
+	order := "random()"
+	if opts.Weighted {
+		order = weightOrder
+	}
+
+	// draw picks a quote, skipping the drawn ones and the recent ones if asked to
+	draw := func(ctx context.Context, tx *sqlighter.Tx, skipDrawn bool, skipRecent bool) (Quote, bool, error) {
+		query := "SELECT * FROM Quotes WHERE guildId = ?"
+		args := []any{guildID}
+		if len(user) > 0 {
+			query += " AND " + byUser("Quotes")
+			args = append(args, user, user)
+		}
+		if skipDrawn {
+			query += " AND id NOT IN (SELECT quoteId FROM QuoteBags WHERE guildId = ? AND user = ?)"
+			args = append(args, guildID, user)
+		}
+		if skipRecent {
+			query += " AND id NOT IN (SELECT quoteId FROM QuotesShown WHERE guildId = ? ORDER BY id DESC LIMIT ?)"
+			args = append(args, guildID, opts.Recent)
+		}
+		query += " ORDER BY " + order + " LIMIT 1"
+
+		q := Quote{}
+		err := tx.GetContext(ctx, &q, query, args...)
+		if errors.Is(err, sql.ErrNoRows) {
+			return q, false, nil
+		}
+		if err != nil {
+			return q, false, fmt.Errorf("failure drawing a quote: %w", err)
+		}
+		return q, true, nil
+	}
+
+	return res, storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
+		q, ok, err := draw(ctx, tx, true, true)
+		if err == nil && !ok {
+			q, ok, err = draw(ctx, tx, true, false)
+		}
+		if err != nil {
+			return err
+		}
+
+		// Every quote was drawn, so the bag starts over
+		if !ok {
+			_, err = tx.ExecContext(ctx, "DELETE FROM QuoteBags WHERE guildId = ? AND user = ?", guildID, user)
+			if err != nil {
+				return fmt.Errorf("failure resetting the shuffle bag: %w", err)
+			}
+
+			q, ok, err = draw(ctx, tx, false, true)
+			if err == nil && !ok {
+				q, ok, err = draw(ctx, tx, false, false)
+			}
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("failure drawing a quote: %w", sql.ErrNoRows)
+			}
+		}
+
+		quotes := []Quote{q}
+		if err := loadLines(ctx, tx, quotes); err != nil {
+			return err
+		}
+		res = quotes[0]
+
+		_, err = tx.ExecContext(ctx, "INSERT OR IGNORE INTO QuoteBags (guildId, user, quoteId) VALUES(?, ?, ?)", guildID, user, q.ID)
+		if err != nil {
+			return fmt.Errorf("failure saving the shuffle bag: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, "INSERT INTO QuotesShown (guildId, quoteId) VALUES(?, ?)", guildID, q.ID)
+		if err != nil {
+			return fmt.Errorf("failure saving a shown quote: %w", err)
+		}
+		// Only the window of recent quotes is kept
+		_, err = tx.ExecContext(ctx,
+			"DELETE FROM QuotesShown WHERE guildId = ? AND id NOT IN (SELECT id FROM QuotesShown WHERE guildId = ? ORDER BY id DESC LIMIT ?)",
+			guildID, guildID, opts.Recent,
+		)
+		if err != nil {
+			return fmt.Errorf("failure trimming shown quotes: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,91 @@
+package quote
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LeBulldoge/gungus/internal/database"
+)
+
+func TestDrawQuote(t *testing.T) {
+	ctx := context.Background()
+	storage := database.New(t.TempDir())
+	if err := storage.Open(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer storage.Close()
+
+	opts := BagOptions{Recent: 1}
+	if _, err := DrawQuote(ctx, storage, "guild", "", opts); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("wrong error received without quotes. got %v, expected, %v", err, sql.ErrNoRows)
+	}
+
+	for _, user := range []string{"a", "b", "c"} {
+		_, err := AddQuote(ctx, storage, Quote{User: user, Text: "hi", Date: time.Now(), GuildID: "guild"})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// every quote is drawn once before any is drawn again
+	for _, weighted := range []bool{false, true} {
+		opts.Weighted = weighted
+		seen := map[int64]bool{}
+		var last int64
+		for range 3 {
+			q, err := DrawQuote(ctx, storage, "guild", "", opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if seen[q.ID] {
+				t.Fatalf("quote drawn twice from the bag. got %d, after, %v", q.ID, seen)
+			}
+			seen[q.ID] = true
+			last = q.ID
+		}
+
+		// the bag starts over, skipping the recent quote
+		q, err := DrawQuote(ctx, storage, "guild", "", opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if q.ID == last {
+			t.Fatalf("recent quote drawn after reset. got %d", q.ID)
+		}
+		// finish the bag for the next round
+		for range 2 {
+			if _, err := DrawQuote(ctx, storage, "guild", "", opts); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	q, err := DrawQuote(ctx, storage, "guild", "b", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.User != "b" {
+		t.Fatalf("wrong user received. got %q, expected, %q", q.User, "b")
+	}
+}
+
+func TestWeight(t *testing.T) {
+	tests := []struct {
+		score int
+		want  float64
+	}{
+		{0, 1},
+		{3, 4},
+		{-1, 0.5},
+		{-3, 0.25},
+	}
+
+	for _, tt := range tests {
+		if got := Weight(tt.score); got != tt.want {
+			t.Errorf("wrong weight received for %d. got %v, expected, %v", tt.score, got, tt.want)
+		}
+	}
+}
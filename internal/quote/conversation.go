@@ -0,0 +1,111 @@
+package quote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/LeBulldoge/sqlighter"
+)
+
+// Line is a line of a conversation quote.
+type Line struct {
+	QuoteID  int64 `db:"quoteId"`
+	Position int
+	// User is who said the line, empty for non-members
+	User string
+	// Name is the name of the speaker, their display name at the time for members
+	Name string
+	Text string
+}
+
+var (
+	ErrNoLines   = errors.New("the conversation has no lines")
+	ErrNoSpeaker = errors.New("the first line has no speaker")
+)
+
+// speakerPattern matches lines starting with the speaker, like "Alice: hi" or "<@123>: hi".
+var speakerPattern = regexp.MustCompile(`^([^:]{1,32}):\s*(.*)$`)
+
+// ParseLines reads the lines of a conversation from text, one line per speaker like
+// "Alice: hi there". Lines without a speaker continue the line before them.
+// resolve returns the user and the name of the speaker called name, with an empty
+// user for non-members.
+func ParseLines(text string, resolve func(name string) (user string, speaker string)) ([]Line, error) {
+	lines := []Line{}
+	for _, raw := range strings.Split(text, "\n") {
+		raw = strings.TrimSpace(raw)
+		if len(raw) == 0 {
+			continue
+		}
+
+		match := speakerPattern.FindStringSubmatch(raw)
+		if match == nil {
+			if len(lines) == 0 {
+				return nil, ErrNoSpeaker
+			}
+			lines[len(lines)-1].Text += "\n" + raw
+			continue
+		}
+
+		user, name := resolve(strings.TrimSpace(match[1]))
+		lines = append(lines, Line{
+			Position: len(lines),
+			User:     user,
+			Name:     name,
+			Text:     match[2],
+		})
+	}
+	if len(lines) == 0 {
+		return nil, ErrNoLines
+	}
+
+	return lines, nil
+}
+
+// Dialogue writes lines as the plain text of a conversation quote, which is what its search matches.
+func Dialogue(lines []Line) string {
+	var sb strings.Builder
+	for i, l := range lines {
+		if i > 0 {
+			sb.WriteRune('\n')
+		}
+		sb.WriteString(l.Name + ": " + l.Text)
+	}
+	return sb.String()
+}
+
+// byUser is the condition matching the quotes in table by user, those of conversations
+// included. It takes user twice as its arguments.
+func byUser(table string) string {
+	return fmt.Sprintf("(%[1]s.user = ? OR %[1]s.id IN (SELECT quoteId FROM QuoteLines WHERE user = ?))", table)
+}
+
+// loadLines fills in the lines of the conversations among quotes.
+func loadLines(ctx context.Context, tx *sqlighter.Tx, quotes []Quote) error {
+	if len(quotes) == 0 {
+		return nil
+	}
+
+	index := make(map[int64]int, len(quotes))
+	args := make([]any, 0, len(quotes))
+	for i, q := range quotes {
+		index[q.ID] = i
+		args = append(args, q.ID)
+	}
+
+	lines := []Line{}
+	query := "SELECT * FROM QuoteLines WHERE quoteId IN (?" + strings.Repeat(", ?", len(args)-1) + ") ORDER BY quoteId, position"
+	err := tx.SelectContext(ctx, &lines, query, args...)
+	if err != nil {
+		return fmt.Errorf("failure getting the lines of quotes: %w", err)
+	}
+	for _, l := range lines {
+		q := &quotes[index[l.QuoteID]]
+		q.Lines = append(q.Lines, l)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package quote
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseLines(t *testing.T) {
+	resolve := func(name string) (string, string) {
+		if name == "Gungus" {
+			return "1", "Gungus"
+		}
+		return "", name
+	}
+
+	tests := []struct {
+		text      string
+		wantLines []Line
+		wantErr   error
+	}{
+		{"", nil, ErrNoLines},
+		{"no speaker here", nil, ErrNoSpeaker},
+		{
+			"Gungus: Who ate my pizza?\n\n  Bob:Not me\nhonestly",
+			[]Line{
+				{Position: 0, User: "1", Name: "Gungus", Text: "Who ate my pizza?"},
+				{Position: 1, Name: "Bob", Text: "Not me\nhonestly"},
+			},
+			nil,
+		},
+		{"Gungus: it's 12:30", []Line{{Position: 0, User: "1", Name: "Gungus", Text: "it's 12:30"}}, nil},
+	}
+
+	for _, tt := range tests {
+		lines, err := ParseLines(tt.text, resolve)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("wrong error received for %q. got %v, expected, %v", tt.text, err, tt.wantErr)
+		}
+		if !reflect.DeepEqual(lines, tt.wantLines) {
+			t.Errorf("wrong lines received for %q. got %+v, expected, %+v", tt.text, lines, tt.wantLines)
+		}
+	}
+
+	lines, _ := ParseLines("Gungus: hi\nBob: hello", resolve)
+	if got, want := Dialogue(lines), "Gungus: hi\nBob: hello"; got != want {
+		t.Errorf("wrong dialogue received. got %q, expected, %q", got, want)
+	}
+}
@@ -0,0 +1,196 @@
+package quote
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/LeBulldoge/gungus/internal/database"
+	"github.com/LeBulldoge/sqlighter"
+)
+
+// DailyTimeLayout is the layout of the time of day quotes of the day are posted at.
+const DailyTimeLayout = "15:04"
+
+// Daily is the quote of the day schedule of a guild.
+type Daily struct {
+	GuildID   string `db:"guildId"`
+	ChannelID string `db:"channelId"`
+	// Time is the time of day to post at, in DailyTimeLayout
+	Time string
+	// Timezone is the IANA name of the timezone of Time, like "Europe/Berlin"
+	Timezone string
+	// LastPostedOn is the date of the last post in the timezone, in time.DateOnly
+	LastPostedOn string `db:"lastPostedOn"`
+}
+
+// Next returns when the quote of the day is posted next: at the time of day in the timezone,
+// today unless it was posted today already, otherwise tomorrow. It is before now when a post is due.
+func (d Daily) Next(now time.Time) (time.Time, error) {
+	loc, err := time.LoadLocation(d.Timezone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failure loading timezone %s: %w", d.Timezone, err)
+	}
+	at, err := time.Parse(DailyTimeLayout, d.Time)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failure parsing time %s: %w", d.Time, err)
+	}
+
+	local := now.In(loc)
+	next := time.Date(local.Year(), local.Month(), local.Day(), at.Hour(), at.Minute(), 0, 0, loc)
+	if local.Format(time.DateOnly) == d.LastPostedOn {
+		next = next.AddDate(0, 0, 1)
+	}
+
+	return next, nil
+}
+
+// Due reports whether the quote of the day should be posted at now.
+func (d Daily) Due(now time.Time) (bool, error) {
+	next, err := d.Next(now)
+	if err != nil {
+		return false, err
+	}
+
+	return !now.Before(next), nil
+}
+
+// Today returns the date of now in the timezone of d, in time.DateOnly.
+func (d Daily) Today(now time.Time) string {
+	loc, err := time.LoadLocation(d.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	return now.In(loc).Format(time.DateOnly)
+}
+
+// SetDaily creates or replaces the schedule of a guild.
+func SetDaily(ctx context.Context, storage *database.Storage, d Daily) error {
+	return storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
+		_, err := tx.ExecContext(ctx,
+			"INSERT OR REPLACE INTO QuoteOfTheDay (guildId, channelId, time, timezone, lastPostedOn) VALUES(?, ?, ?, ?, ?)",
+			d.GuildID, d.ChannelID, d.Time, d.Timezone, d.LastPostedOn,
+		)
+		if err != nil {
+			return fmt.Errorf("failure saving the quote of the day schedule: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetDaily returns the schedule of a guild. It returns sql.ErrNoRows if there is none.
+func GetDaily(ctx context.Context, storage *database.Storage, guildID string) (Daily, error) {
+	res := Daily{}
+
+	return res, storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
+		err := tx.GetContext(ctx, &res, "SELECT * FROM QuoteOfTheDay WHERE guildId = ?", guildID)
+		if err != nil {
+			return fmt.Errorf("failure getting the quote of the day schedule: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetDailies returns the schedules of every guild.
+func GetDailies(ctx context.Context, storage *database.Storage) ([]Daily, error) {
+	res := []Daily{}
+
+	return res, storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
+		err := tx.SelectContext(ctx, &res, "SELECT * FROM QuoteOfTheDay")
+		if err != nil {
+			return fmt.Errorf("failure getting the quote of the day schedules: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// DeleteDaily removes the schedule of a guild, reporting whether there was one.
+func DeleteDaily(ctx context.Context, storage *database.Storage, guildID string) (bool, error) {
+	var deleted bool
+
+	return deleted, storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
+		res, err := tx.ExecContext(ctx, "DELETE FROM QuoteOfTheDay WHERE guildId = ?", guildID)
+		if err != nil {
+			return fmt.Errorf("failure deleting the quote of the day schedule: %w", err)
+		}
+
+		n, err := res.RowsAffected()
+		deleted = n > 0
+		return err
+	})
+}
+
+// SetDailyPostedOn records that the quote of the day of a guild was handled on date.
+func SetDailyPostedOn(ctx context.Context, storage *database.Storage, guildID string, date string) error {
+	return storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
+		_, err := tx.ExecContext(ctx, "UPDATE QuoteOfTheDay SET lastPostedOn = ? WHERE guildId = ?", date, guildID)
+		if err != nil {
+			return fmt.Errorf("failure updating the quote of the day schedule: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetDailyPosted returns the IDs of the quotes posted as quote of the day since the pool was last exhausted.
+func GetDailyPosted(ctx context.Context, storage *database.Storage, guildID string) (map[int64]bool, error) {
+	res := map[int64]bool{}
+
+	return res, storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
+		var ids []int64
+		err := tx.SelectContext(ctx, &ids, "SELECT quoteId FROM QuoteOfTheDayPosted WHERE guildId = ?", guildID)
+		if err != nil {
+			return fmt.Errorf("failure getting the posted quotes of the day: %w", err)
+		}
+		for _, id := range ids {
+			res[id] = true
+		}
+
+		return nil
+	})
+}
+
+// AddDailyPosted records that quoteID was posted as quote of the day. With reset the
+// quotes posted before are forgotten first, starting over with the whole pool.
+func AddDailyPosted(ctx context.Context, storage *database.Storage, guildID string, quoteID int64, reset bool) error {
+	return storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
+		if reset {
+			_, err := tx.ExecContext(ctx, "DELETE FROM QuoteOfTheDayPosted WHERE guildId = ?", guildID)
+			if err != nil {
+				return fmt.Errorf("failure resetting the posted quotes of the day: %w", err)
+			}
+		}
+
+		_, err := tx.ExecContext(ctx, "INSERT OR IGNORE INTO QuoteOfTheDayPosted (guildId, quoteId) VALUES(?, ?)", guildID, quoteID)
+		if err != nil {
+			return fmt.Errorf("failure saving a posted quote of the day: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// PickDaily picks a random quote that wasn't posted yet. Once every quote was posted,
+// it picks from all of them again and reports that the posted ones should be reset.
+// intn returns a random number in [0, n), like rand.Intn.
+func PickDaily(quotes []Quote, posted map[int64]bool, intn func(n int) int) (q Quote, reset bool, ok bool) {
+	if len(quotes) == 0 {
+		return Quote{}, false, false
+	}
+
+	candidates := []Quote{}
+	for _, q := range quotes {
+		if !posted[q.ID] {
+			candidates = append(candidates, q)
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = quotes
+		reset = true
+	}
+
+	return candidates[intn(len(candidates))], reset, true
+}
@@ -0,0 +1,68 @@
+package quote
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDailyDue(t *testing.T) {
+	// 2024-03-10 07:30 UTC is 08:30 in Berlin
+	now := time.Date(2024, 3, 10, 7, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		daily    Daily
+		wantNext string
+		wantDue  bool
+	}{
+		{Daily{Time: "09:00", Timezone: "UTC"}, "2024-03-10T09:00:00Z", false},
+		{Daily{Time: "07:00", Timezone: "UTC"}, "2024-03-10T07:00:00Z", true},
+		{Daily{Time: "07:00", Timezone: "UTC", LastPostedOn: "2024-03-10"}, "2024-03-11T07:00:00Z", false},
+		{Daily{Time: "07:00", Timezone: "UTC", LastPostedOn: "2024-03-09"}, "2024-03-10T07:00:00Z", true},
+		{Daily{Time: "08:30", Timezone: "Europe/Berlin"}, "2024-03-10T07:30:00Z", true},
+		{Daily{Time: "09:00", Timezone: "Europe/Berlin"}, "2024-03-10T08:00:00Z", false},
+		{Daily{Time: "00:15", Timezone: "America/New_York", LastPostedOn: "2024-03-10"}, "2024-03-11T04:15:00Z", false},
+	}
+
+	for _, tt := range tests {
+		next, err := tt.daily.Next(now)
+		if err != nil {
+			t.Fatalf("error received. got %v, expected, nil", err)
+		}
+		if got := next.UTC().Format(time.RFC3339); got != tt.wantNext {
+			t.Errorf("wrong next post received for %+v. got %v, expected, %v", tt.daily, got, tt.wantNext)
+		}
+		if due, _ := tt.daily.Due(now); due != tt.wantDue {
+			t.Errorf("wrong due received for %+v. got %v, expected, %v", tt.daily, due, tt.wantDue)
+		}
+	}
+
+	if _, err := (Daily{Time: "9am", Timezone: "UTC"}).Next(now); err == nil {
+		t.Errorf("error received. got nil, expected, an invalid time error")
+	}
+}
+
+func TestPickDaily(t *testing.T) {
+	quotes := []Quote{{ID: 1}, {ID: 2}, {ID: 3}}
+	last := func(n int) int { return n - 1 }
+
+	tests := []struct {
+		quotes    []Quote
+		posted    map[int64]bool
+		wantID    int64
+		wantReset bool
+		wantOk    bool
+	}{
+		{nil, nil, 0, false, false},
+		{quotes, map[int64]bool{}, 3, false, true},
+		{quotes, map[int64]bool{3: true}, 2, false, true},
+		{quotes, map[int64]bool{2: true, 3: true}, 1, false, true},
+		{quotes, map[int64]bool{1: true, 2: true, 3: true}, 3, true, true},
+	}
+
+	for _, tt := range tests {
+		q, reset, ok := PickDaily(tt.quotes, tt.posted, last)
+		if q.ID != tt.wantID || reset != tt.wantReset || ok != tt.wantOk {
+			t.Errorf("wrong pick received for %v. got %d %v %v, expected, %d %v %v", tt.posted, q.ID, reset, ok, tt.wantID, tt.wantReset, tt.wantOk)
+		}
+	}
+}
@@ -2,7 +2,11 @@ package quote
 
 import (
 	"context"
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/LeBulldoge/gungus/internal/database"
@@ -10,27 +14,166 @@ import (
 )
 
 type Quote struct {
-	User string
-	Text string
-	Date time.Time
+	ID      int64
+	User    string
+	Text    string
+	Date    time.Time
+	GuildID string `db:"guildId"`
+	// ChannelID and MessageID are set for quotes saved from a message
+	ChannelID   string      `db:"channelId"`
+	MessageID   string      `db:"messageId"`
+	Attachments Attachments `db:"attachments"`
+	// SubmittedBy is the user who saved the quote, empty for quotes saved before it was kept
+	SubmittedBy string `db:"submittedBy"`
+	// Score ranks the quote, weighting random quotes when a guild enables it
+	Score int
+	// Lines are the lines of conversation quotes, whose User is the first member speaking,
+	// if any, and whose Text is their Dialogue
+	Lines []Line `db:"-"`
 }
 
-func AddQuote(ctx context.Context, storage *database.Storage, user string, text string, date time.Time) error {
-	return storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
-		_, err := tx.ExecContext(ctx, "INSERT INTO Quotes VALUES(?, ?, ?)", user, text, date.UTC())
+// Link returns the jump link to the message the quote was saved from, if any.
+func (q Quote) Link() string {
+	if len(q.MessageID) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("https://discord.com/channels/%s/%s/%s", q.GuildID, q.ChannelID, q.MessageID)
+}
+
+// Attachments are the IDs of the files attached to a quoted message, stored as a JSON array.
+// Their URLs are signed and expire, so they're looked up on the message when the quote is shown.
+// Quotes saved before hold the URLs, see AttachmentID.
+type Attachments []string
+
+// AttachmentID returns the ID of an attachment of a quote, which is a URL like
+// "https://cdn.discordapp.com/attachments/<channel>/<attachment>/<file>" for older quotes.
+func AttachmentID(a string) string {
+	u, err := url.Parse(a)
+	if err != nil || len(u.Host) == 0 {
+		return a
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 3 || parts[0] != "attachments" {
+		return a
+	}
+	return parts[2]
+}
+
+func (a Attachments) Value() (driver.Value, error) {
+	if a == nil {
+		a = Attachments{}
+	}
+	b, err := json.Marshal(a)
+	return string(b), err
+}
+
+func (a *Attachments) Scan(src any) error {
+	var b []byte
+	switch src := src.(type) {
+	case string:
+		b = []byte(src)
+	case []byte:
+		b = src
+	case nil:
+		*a = nil
+		return nil
+	default:
+		return fmt.Errorf("unsupported attachments type %T", src)
+	}
+
+	return json.Unmarshal(b, a)
+}
+
+// AddQuote saves q, with its lines for conversations, and returns its ID.
+func AddQuote(ctx context.Context, storage *database.Storage, q Quote) (int64, error) {
+	var id int64
+
+	return id, storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
+		res, err := tx.ExecContext(ctx,
+			"INSERT INTO Quotes (user, text, date, guildId, channelId, messageId, attachments, submittedBy) VALUES(?, ?, ?, ?, ?, ?, ?, ?)",
+			q.User, q.Text, q.Date.UTC(), q.GuildID, q.ChannelID, q.MessageID, q.Attachments, q.SubmittedBy,
+		)
 		if err != nil {
 			return fmt.Errorf("failure saving a quote: %w", err)
 		}
 
+		id, err = res.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failure getting the id of a quote: %w", err)
+		}
+
+		for i, l := range q.Lines {
+			_, err = tx.ExecContext(ctx,
+				"INSERT INTO QuoteLines (quoteId, position, user, name, text) VALUES(?, ?, ?, ?, ?)",
+				id, i, l.User, l.Name, l.Text,
+			)
+			if err != nil {
+				return fmt.Errorf("failure saving the lines of a quote: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetQuote returns the quote id of the guild. It returns sql.ErrNoRows if there is none.
+func GetQuote(ctx context.Context, storage *database.Storage, guildID string, id int64) (Quote, error) {
+	res := Quote{}
+
+	return res, storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
+		err := tx.GetContext(ctx, &res, "SELECT * FROM Quotes WHERE guildId = ? AND id = ?", guildID, id)
+		if err != nil {
+			return fmt.Errorf("failure getting quote %d: %w", id, err)
+		}
+
+		quotes := []Quote{res}
+		if err := loadLines(ctx, tx, quotes); err != nil {
+			return err
+		}
+		res = quotes[0]
+
+		return nil
+	})
+}
+
+// UpdateQuote changes the text and the author of a quote.
+func UpdateQuote(ctx context.Context, storage *database.Storage, q Quote) error {
+	return storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
+		_, err := tx.ExecContext(ctx, "UPDATE Quotes SET user = ?, text = ? WHERE guildId = ? AND id = ?", q.User, q.Text, q.GuildID, q.ID)
+		if err != nil {
+			return fmt.Errorf("failure updating quote %d: %w", q.ID, err)
+		}
+
 		return nil
 	})
 }
 
-func GetQuotesByUser(ctx context.Context, storage *database.Storage, user string) ([]Quote, error) {
+// DeleteQuote deletes the quote id of the guild, reporting whether there was one.
+func DeleteQuote(ctx context.Context, storage *database.Storage, guildID string, id int64) (bool, error) {
+	var deleted bool
+
+	return deleted, storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
+		res, err := tx.ExecContext(ctx, "DELETE FROM Quotes WHERE guildId = ? AND id = ?", guildID, id)
+		if err != nil {
+			return fmt.Errorf("failure deleting quote %d: %w", id, err)
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failure deleting quote %d: %w", id, err)
+		}
+		deleted = n > 0
+
+		return nil
+	})
+}
+
+func GetQuotesByUser(ctx context.Context, storage *database.Storage, guildID string, user string) ([]Quote, error) {
 	res := []Quote{}
 
 	return res, storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
-		err := tx.SelectContext(ctx, &res, "SELECT * FROM Quotes WHERE user = ?", user)
+		err := tx.SelectContext(ctx, &res, "SELECT * FROM Quotes WHERE guildId = ? AND user = ?", guildID, user)
 		if err != nil {
 			return fmt.Errorf("failure getting a quote for user %s: %w", user, err)
 		}
@@ -39,15 +182,15 @@ func GetQuotesByUser(ctx context.Context, storage *database.Storage, user string
 	})
 }
 
-func GetQuotes(ctx context.Context, storage *database.Storage) ([]Quote, error) {
+func GetQuotes(ctx context.Context, storage *database.Storage, guildID string) ([]Quote, error) {
 	res := []Quote{}
 
 	return res, storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
-		err := tx.SelectContext(ctx, &res, "SELECT * FROM Quotes")
+		err := tx.SelectContext(ctx, &res, "SELECT * FROM Quotes WHERE guildId = ?", guildID)
 		if err != nil {
 			return fmt.Errorf("failure getting a quotes: %w", err)
 		}
 
-		return nil
+		return loadLines(ctx, tx, res)
 	})
 }
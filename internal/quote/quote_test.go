@@ -0,0 +1,21 @@
+package quote
+
+import "testing"
+
+func TestAttachmentID(t *testing.T) {
+	tests := []struct {
+		attachment string
+		want       string
+	}{
+		{"1150000000000000002", "1150000000000000002"},
+		{"https://cdn.discordapp.com/attachments/1150000000000000001/1150000000000000002/cat.png?ex=65&is=64&hm=ab", "1150000000000000002"},
+		{"https://media.discordapp.net/attachments/1150000000000000001/1150000000000000002/cat.png", "1150000000000000002"},
+		{"https://example.com/cat.png", "https://example.com/cat.png"},
+	}
+
+	for _, tt := range tests {
+		if got := AttachmentID(tt.attachment); got != tt.want {
+			t.Errorf("wrong ID received. got %q, expected, %q", got, tt.want)
+		}
+	}
+}
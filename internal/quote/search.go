@@ -0,0 +1,90 @@
+package quote
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/LeBulldoge/gungus/internal/database"
+	"github.com/LeBulldoge/sqlighter"
+)
+
+// Highlight marks the matched terms in the text of search results.
+const Highlight = "**"
+
+// snippetTokens is how many words of long quotes are kept around the matched terms.
+const snippetTokens = 48
+
+// SearchResult is a quote matching a search, with the matched terms of its text highlighted.
+// Highlighted is cut down to the part around the matched terms for long quotes.
+type SearchResult struct {
+	Quote
+	Highlighted string `db:"highlighted"`
+}
+
+// SearchTerms splits text into the words a search matches on, dropping punctuation
+// and anything else the search index doesn't keep.
+func SearchTerms(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// matchQuery builds an FTS5 query matching quotes containing every term,
+// each as the prefix of a word so results show up while typing.
+func matchQuery(terms []string) string {
+	parts := make([]string, 0, len(terms))
+	for _, term := range terms {
+		parts = append(parts, `"`+term+`"*`)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Search returns the quotes of the guild containing every word of text, best matches first,
+// and how many there are in total. Quotes are limited to those by or with user unless it's empty.
+func Search(ctx context.Context, storage *database.Storage, guildID string, text string, user string, limit int, offset int) ([]SearchResult, int, error) {
+	res := []SearchResult{}
+	var total int
+
+	terms := SearchTerms(text)
+	if len(terms) == 0 {
+		return res, 0, nil
+	}
+
+	where := "QuotesSearch MATCH ? AND q.guildId = ?"
+	args := []any{matchQuery(terms), guildID}
+	if len(user) > 0 {
+		where += " AND " + byUser("q")
+		args = append(args, user, user)
+	}
+
+	return res, total, storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
+		err := tx.GetContext(ctx, &total, "SELECT COUNT(*) FROM QuotesSearch JOIN Quotes q ON q.id = QuotesSearch.rowid WHERE "+where, args...)
+		if err != nil {
+			return fmt.Errorf("failure counting quotes matching %q: %w", text, err)
+		}
+
+		query := fmt.Sprintf(
+			"SELECT q.*, snippet(QuotesSearch, 0, '%s', '%s', '…', %d) AS highlighted FROM QuotesSearch JOIN Quotes q ON q.id = QuotesSearch.rowid WHERE %s ORDER BY rank LIMIT ? OFFSET ?",
+			Highlight, Highlight, snippetTokens, where,
+		)
+		err = tx.SelectContext(ctx, &res, query, append(args, limit, offset)...)
+		if err != nil {
+			return fmt.Errorf("failure searching quotes matching %q: %w", text, err)
+		}
+
+		quotes := make([]Quote, len(res))
+		for i, r := range res {
+			quotes[i] = r.Quote
+		}
+		if err := loadLines(ctx, tx, quotes); err != nil {
+			return err
+		}
+		for i := range res {
+			res[i].Lines = quotes[i].Lines
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,30 @@
+package quote
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSearchTerms(t *testing.T) {
+	tests := []struct {
+		text      string
+		wantTerms []string
+		wantQuery string
+	}{
+		{"", []string{}, ""},
+		{"cat", []string{"cat"}, `"cat"*`},
+		{`  "Cats" are great, café!`, []string{"Cats", "are", "great", "café"}, `"Cats"* "are"* "great"* "café"*`},
+		{`a*b OR c:d`, []string{"a", "b", "OR", "c", "d"}, `"a"* "b"* "OR"* "c"* "d"*`},
+		{"кот 42", []string{"кот", "42"}, `"кот"* "42"*`},
+	}
+
+	for _, tt := range tests {
+		terms := SearchTerms(tt.text)
+		if !slices.Equal(terms, tt.wantTerms) {
+			t.Errorf("wrong terms received. got %q, expected, %q", terms, tt.wantTerms)
+		}
+		if query := matchQuery(terms); query != tt.wantQuery {
+			t.Errorf("wrong query received. got %q, expected, %q", query, tt.wantQuery)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package quote
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/LeBulldoge/gungus/internal/database"
+	"github.com/LeBulldoge/sqlighter"
+)
+
+// Votes counts the upvotes and downvotes of a quote.
+type Votes struct {
+	Up   int
+	Down int
+}
+
+// Score is the score of a quote with the votes v.
+func (v Votes) Score() int {
+	return v.Up - v.Down
+}
+
+// Quoted is a member with the number of their quotes in a guild and their total score.
+type Quoted struct {
+	User   string
+	Quotes int
+	Score  int
+}
+
+const votesQuery = "SELECT COALESCE(SUM(value = 1), 0) AS up, COALESCE(SUM(value = -1), 0) AS down FROM QuoteVotes WHERE quoteId = ?"
+
+// Vote records the vote of voter on the quote id of the guild, 1 for an upvote or -1 for a downvote,
+// and updates the score of the quote. Voting the same way again takes the vote back.
+// It returns the votes of the quote, or sql.ErrNoRows if there is no such quote.
+func Vote(ctx context.Context, storage *database.Storage, guildID string, id int64, voter string, value int) (Votes, error) {
+	res := Votes{}
+
+	return res, storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
+		var found int64
+		err := tx.GetContext(ctx, &found, "SELECT id FROM Quotes WHERE guildId = ? AND id = ?", guildID, id)
+		if err != nil {
+			return fmt.Errorf("failure getting quote %d: %w", id, err)
+		}
+
+		deleted, err := tx.ExecContext(ctx, "DELETE FROM QuoteVotes WHERE quoteId = ? AND voter = ? AND value = ?", id, voter, value)
+		if err != nil {
+			return fmt.Errorf("failure taking back a vote on quote %d: %w", id, err)
+		}
+		n, err := deleted.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failure taking back a vote on quote %d: %w", id, err)
+		}
+		if n == 0 {
+			_, err = tx.ExecContext(ctx,
+				"INSERT OR REPLACE INTO QuoteVotes (quoteId, voter, value, date) VALUES(?, ?, ?, ?)",
+				id, voter, value, time.Now().UTC(),
+			)
+			if err != nil {
+				return fmt.Errorf("failure saving a vote on quote %d: %w", id, err)
+			}
+		}
+
+		err = tx.GetContext(ctx, &res, votesQuery, id)
+		if err != nil {
+			return fmt.Errorf("failure counting the votes on quote %d: %w", id, err)
+		}
+		_, err = tx.ExecContext(ctx, "UPDATE Quotes SET score = ? WHERE id = ?", res.Score(), id)
+		if err != nil {
+			return fmt.Errorf("failure updating the score of quote %d: %w", id, err)
+		}
+
+		return nil
+	})
+}
+
+// GetVotes returns the votes on the quote id.
+func GetVotes(ctx context.Context, storage *database.Storage, id int64) (Votes, error) {
+	res := Votes{}
+
+	return res, storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
+		err := tx.GetContext(ctx, &res, votesQuery, id)
+		if err != nil {
+			return fmt.Errorf("failure counting the votes on quote %d: %w", id, err)
+		}
+
+		return nil
+	})
+}
+
+// GetTopQuotes returns the quotes of the guild with the highest scores, saved since since
+// unless it's zero, and by or with user unless it's empty. Quotes without a positive score are left out.
+func GetTopQuotes(ctx context.Context, storage *database.Storage, guildID string, user string, since time.Time, limit int) ([]Quote, error) {
+	res := []Quote{}
+
+	query := "SELECT * FROM Quotes WHERE guildId = ? AND score > 0"
+	args := []any{guildID}
+	if len(user) > 0 {
+		query += " AND " + byUser("Quotes")
+		args = append(args, user, user)
+	}
+	if !since.IsZero() {
+		query += " AND date >= ?"
+		args = append(args, since.UTC())
+	}
+
+	return res, storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
+		err := tx.SelectContext(ctx, &res, query+" ORDER BY score DESC, date DESC LIMIT ?", append(args, limit)...)
+		if err != nil {
+			return fmt.Errorf("failure getting the top quotes: %w", err)
+		}
+
+		return loadLines(ctx, tx, res)
+	})
+}
+
+// GetLeaderboard returns the most quoted members of the guild, ties broken by their total score.
+// Every member speaking in a conversation is quoted by it.
+func GetLeaderboard(ctx context.Context, storage *database.Storage, guildID string, limit int) ([]Quoted, error) {
+	res := []Quoted{}
+
+	return res, storage.Tx(ctx, func(ctx context.Context, tx *sqlighter.Tx) error {
+		err := tx.SelectContext(ctx, &res,
+			`SELECT quoted.user, COUNT(*) AS quotes, SUM(Quotes.score) AS score FROM (
+				SELECT id AS quoteId, user FROM Quotes WHERE guildId = ? AND user != ''
+				UNION
+				SELECT QuoteLines.quoteId, QuoteLines.user FROM QuoteLines
+				JOIN Quotes ON Quotes.id = QuoteLines.quoteId
+				WHERE Quotes.guildId = ? AND QuoteLines.user != ''
+			) AS quoted
+			JOIN Quotes ON Quotes.id = quoted.quoteId
+			GROUP BY quoted.user ORDER BY quotes DESC, score DESC LIMIT ?`,
+			guildID, guildID, limit,
+		)
+		if err != nil {
+			return fmt.Errorf("failure getting the quote leaderboard: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,48 @@
+package quote
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/LeBulldoge/gungus/internal/database"
+)
+
+func TestGetLeaderboard(t *testing.T) {
+	ctx := context.Background()
+	storage := database.New(t.TempDir())
+	if err := storage.Open(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer storage.Close()
+
+	quotes := []Quote{
+		{User: "a", Text: "hi"},
+		{User: "b", Text: "hey"},
+		{User: "a", Text: "a: hi\nb: hey\na: bye", Lines: []Line{{User: "a", Text: "hi"}, {User: "b", Text: "hey"}, {User: "a", Text: "bye"}}},
+		{User: "c", Text: "c: hi\nguest: hey", Lines: []Line{{User: "c", Text: "hi"}, {Name: "guest", Text: "hey"}}},
+	}
+	for _, q := range quotes {
+		q.Date = time.Now()
+		q.GuildID = "guild"
+		if _, err := AddQuote(ctx, storage, q); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	res, err := GetLeaderboard(ctx, storage, "guild", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Quoted{{User: "a", Quotes: 2}, {User: "b", Quotes: 2}, {User: "c", Quotes: 1}}
+	if len(res) != len(want) {
+		t.Fatalf("wrong leaderboard received. got %+v, expected, %+v", res, want)
+	}
+	for _, w := range want {
+		if !slices.Contains(res, w) {
+			t.Fatalf("wrong leaderboard received. got %+v, expected, %+v", res, want)
+		}
+	}
+}
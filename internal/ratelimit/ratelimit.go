@@ -0,0 +1,110 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Limit allows Burst requests at once, refilling one every Interval.
+type Limit struct {
+	Burst    int
+	Interval time.Duration
+}
+
+// Request asks for a token from the bucket with Key, created with Limit if it doesn't exist.
+type Request struct {
+	Key   string
+	Limit Limit
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	limit  Limit
+}
+
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.last)
+	b.tokens = math.Min(float64(b.limit.Burst), b.tokens+elapsed.Seconds()/b.limit.Interval.Seconds())
+	b.last = now
+}
+
+func (b *bucket) full() bool {
+	return b.tokens >= float64(b.limit.Burst)
+}
+
+// wait returns how long until the bucket has a token.
+func (b *bucket) wait() time.Duration {
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) * float64(b.limit.Interval)).Round(time.Millisecond)
+}
+
+const pruneInterval = time.Minute
+
+// Limiter is a set of token buckets.
+type Limiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+
+	now       func() time.Time
+	lastPrune time.Time
+}
+
+func New() *Limiter {
+	return &Limiter{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Allow takes a token from the bucket of every request, or from none of them if one is empty,
+// in which case it also returns how long to wait until all of them have a token.
+func (l *Limiter) Allow(reqs ...Request) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.prune(now)
+
+	buckets := make([]*bucket, 0, len(reqs))
+	var wait time.Duration
+	for _, req := range reqs {
+		b, ok := l.buckets[req.Key]
+		if !ok || b.limit != req.Limit {
+			b = &bucket{tokens: float64(req.Limit.Burst), last: now, limit: req.Limit}
+			l.buckets[req.Key] = b
+		}
+		b.refill(now)
+
+		wait = max(wait, b.wait())
+		buckets = append(buckets, b)
+	}
+
+	if wait > 0 {
+		return false, wait
+	}
+
+	for _, b := range buckets {
+		b.tokens--
+	}
+
+	return true, 0
+}
+
+// prune forgets full buckets, which behave the same as new ones.
+func (l *Limiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < pruneInterval {
+		return
+	}
+	l.lastPrune = now
+
+	for key, b := range l.buckets {
+		b.refill(now)
+		if b.full() {
+			delete(l.buckets, key)
+		}
+	}
+}
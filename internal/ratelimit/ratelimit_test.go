@@ -0,0 +1,43 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAllow(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := New()
+	l.now = func() time.Time { return now }
+
+	user := Request{Key: "user", Limit: Limit{Burst: 2, Interval: 10 * time.Second}}
+	channel := Request{Key: "channel", Limit: Limit{Burst: 3, Interval: time.Minute}}
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := l.Allow(user, channel); !ok {
+			t.Fatalf("request %d denied", i)
+		}
+	}
+
+	ok, wait := l.Allow(user, channel)
+	if ok {
+		t.Fatal("request allowed with an empty bucket")
+	}
+	if wait != 10*time.Second {
+		t.Fatalf("wrong wait received. got %s, expected, %s", wait, 10*time.Second)
+	}
+
+	// The denied request must not have taken a token from the channel bucket
+	now = now.Add(10 * time.Second)
+	if ok, _ := l.Allow(user, channel); !ok {
+		t.Fatal("request denied after refill")
+	}
+
+	ok, wait = l.Allow(Request{Key: "other", Limit: user.Limit}, channel)
+	if ok {
+		t.Fatal("request allowed with an empty shared bucket")
+	}
+	if wait != 50*time.Second {
+		t.Fatalf("wrong wait received. got %s, expected, %s", wait, 50*time.Second)
+	}
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Server serves the operational endpoints of the bot, like metrics and health checks, over HTTP.
+type Server struct {
+	srv *http.Server
+	mux *http.ServeMux
+
+	logger *slog.Logger
+}
+
+func New(addr string) *Server {
+	mux := http.NewServeMux()
+	return &Server{
+		srv: &http.Server{
+			Addr:              addr,
+			Handler:           mux,
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+		mux:    mux,
+		logger: slog.Default().WithGroup("http"),
+	}
+}
+
+func (s *Server) Handle(pattern string, h http.Handler) {
+	s.mux.Handle(pattern, h)
+}
+
+// Start listens in the background until Shutdown is called.
+func (s *Server) Start() {
+	s.logger.Info("listening", "addr", s.srv.Addr)
+	go func() {
+		err := s.srv.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("error while serving", "err", err)
+		}
+	}()
+}
+
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.srv.Shutdown(ctx)
+}
@@ -0,0 +1,33 @@
+package settings
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+
+	"github.com/LeBulldoge/gungus/internal/database"
+)
+
+// Features are stored among the settings, but aren't listed or edited with them.
+const featurePrefix = "feature."
+
+// FeatureEnabled reports whether the feature, named after its command, is enabled for the guild.
+// Features are enabled unless disabled with SetFeatureEnabled.
+func FeatureEnabled(ctx context.Context, storage *database.Storage, guildID string, feature string) bool {
+	values, err := storage.GuildSettings(ctx, guildID)
+	if err != nil {
+		slog.Error("failure getting guild settings", "guildId", guildID, "err", err)
+		return true
+	}
+
+	enabled, err := strconv.ParseBool(values[featurePrefix+feature])
+	return err != nil || enabled
+}
+
+func SetFeatureEnabled(ctx context.Context, storage *database.Storage, guildID string, feature string, enabled bool) error {
+	if enabled {
+		return storage.DeleteGuildSetting(ctx, guildID, featurePrefix+feature)
+	}
+
+	return storage.SetGuildSetting(ctx, guildID, featurePrefix+feature, strconv.FormatBool(enabled))
+}
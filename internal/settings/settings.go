@@ -0,0 +1,271 @@
+package settings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LeBulldoge/gungus/internal/database"
+)
+
+// Definition describes a setting independently of its type, for listing and editing it.
+type Definition interface {
+	Key() string
+	// Description returns the catalog key of the description of the setting.
+	Description() string
+	// DefaultString formats the value used when a guild hasn't set one.
+	DefaultString() string
+	// Normalize validates a value entered by a user and returns it in its stored form.
+	Normalize(value string) (string, error)
+}
+
+// Setting is a per guild setting of type T.
+type Setting[T any] struct {
+	key string
+	def T
+
+	parse  func(string) (T, error)
+	format func(T) string
+	check  func(T) error
+}
+
+func (s *Setting[T]) Key() string {
+	return s.key
+}
+
+func (s *Setting[T]) Description() string {
+	return "settings.descriptions." + s.key
+}
+
+func (s *Setting[T]) Default() T {
+	return s.def
+}
+
+// SetDefault replaces the default, for settings defaulting to a value of the configuration.
+func (s *Setting[T]) SetDefault(v T) {
+	s.def = v
+}
+
+func (s *Setting[T]) DefaultString() string {
+	return s.format(s.def)
+}
+
+func (s *Setting[T]) Normalize(value string) (string, error) {
+	v, err := s.parse(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid value %q for %s", value, s.key)
+	}
+	if err := s.check(v); err != nil {
+		return "", fmt.Errorf("invalid value %q for %s: %w", value, s.key, err)
+	}
+
+	return s.format(v), nil
+}
+
+// Get returns the value of the setting for the guild, or the default if it isn't set
+// or can't be read.
+func (s *Setting[T]) Get(ctx context.Context, storage *database.Storage, guildID string) T {
+	values, err := storage.GuildSettings(ctx, guildID)
+	if err != nil {
+		slog.Error("failure getting guild settings", "guildId", guildID, "err", err)
+		return s.def
+	}
+
+	v, err := s.lookup(values)
+	if err != nil {
+		slog.Error("failure parsing guild setting", "guildId", guildID, "key", s.key, "value", values[s.key], "err", err)
+		return s.def
+	}
+
+	return v
+}
+
+// lookup returns the value of the setting among the raw values of a guild, or the default if it isn't set.
+func (s *Setting[T]) lookup(values map[string]string) (T, error) {
+	raw, ok := values[s.key]
+	if !ok {
+		return s.def, nil
+	}
+
+	return s.parse(raw)
+}
+
+func newInt(key string, def int, min int, max int) *Setting[int] {
+	return &Setting[int]{
+		key:    key,
+		def:    def,
+		parse:  strconv.Atoi,
+		format: strconv.Itoa,
+		check: func(v int) error {
+			if v < min || v > max {
+				return fmt.Errorf("must be between %d and %d", min, max)
+			}
+			return nil
+		},
+	}
+}
+
+func newFloat(key string, def float64, min float64, max float64) *Setting[float64] {
+	return &Setting[float64]{
+		key: key,
+		def: def,
+		parse: func(s string) (float64, error) {
+			return strconv.ParseFloat(s, 64)
+		},
+		format: func(v float64) string {
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		},
+		check: func(v float64) error {
+			if v < min || v > max {
+				return fmt.Errorf("must be between %g and %g", min, max)
+			}
+			return nil
+		},
+	}
+}
+
+func newBool(key string, def bool) *Setting[bool] {
+	return &Setting[bool]{
+		key:    key,
+		def:    def,
+		parse:  strconv.ParseBool,
+		format: strconv.FormatBool,
+		check:  func(bool) error { return nil },
+	}
+}
+
+func newDuration(key string, def time.Duration, min time.Duration, max time.Duration) *Setting[time.Duration] {
+	return &Setting[time.Duration]{
+		key:    key,
+		def:    def,
+		parse:  time.ParseDuration,
+		format: time.Duration.String,
+		check: func(v time.Duration) error {
+			if v < min || v > max {
+				return fmt.Errorf("must be between %s and %s", min, max)
+			}
+			return nil
+		},
+	}
+}
+
+// newChannel creates a setting holding a channel ID, entered as an ID or a channel mention.
+// It is empty by default. Whether the channel belongs to the guild can't be checked without
+// a session, so callers of Set check it with ops.InGuild.
+func newChannel(key string) *Setting[string] {
+	return &Setting[string]{
+		key: key,
+		parse: func(s string) (string, error) {
+			id := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(s), "<#"), ">")
+			if _, err := strconv.ParseUint(id, 10, 64); err != nil {
+				return "", err
+			}
+			return id, nil
+		},
+		format: func(v string) string {
+			if len(v) == 0 {
+				return "none"
+			}
+			return v
+		},
+		check: func(string) error { return nil },
+	}
+}
+
+var (
+	Volume         = newInt("play.volume", 100, 1, 200)
+	IdleTimeout    = newDuration("play.idle_timeout", time.Minute, 10*time.Second, time.Hour)
+	PollBarLength  = newInt("poll.bar_length", 10, 5, 20)
+	QuoteEphemeral = newBool("quote.ephemeral", false)
+	QuoteWeighted  = newBool("quote.weighted", false)
+	QuoteRecent    = newInt("quote.recent", 10, 0, 100)
+	MovieRatingMin = newFloat("movie.rating_min", -10, -100, 100)
+	MovieRatingMax = newFloat("movie.rating_max", 10, -100, 100)
+	OpsChannel     = newChannel("ops.channel")
+)
+
+// All lists every setting, in the order they are shown.
+var All = []Definition{
+	Volume,
+	IdleTimeout,
+	PollBarLength,
+	QuoteEphemeral,
+	QuoteWeighted,
+	QuoteRecent,
+	MovieRatingMin,
+	MovieRatingMax,
+	OpsChannel,
+}
+
+var ErrUnknownSetting = errors.New("unknown setting")
+
+func Find(key string) (Definition, error) {
+	for _, def := range All {
+		if def.Key() == key {
+			return def, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w %q", ErrUnknownSetting, key)
+}
+
+// Set validates value and saves it as the setting with key for the guild.
+// It returns the value as it was saved.
+func Set(ctx context.Context, storage *database.Storage, guildID string, key string, value string) (string, error) {
+	def, err := Find(key)
+	if err != nil {
+		return "", err
+	}
+
+	value, err = def.Normalize(value)
+	if err != nil {
+		return "", err
+	}
+
+	if err := checkRelated(ctx, storage, guildID, func(values map[string]string) { values[key] = value }); err != nil {
+		return "", fmt.Errorf("invalid value %q for %s: %w", value, key, err)
+	}
+
+	return value, storage.SetGuildSetting(ctx, guildID, key, value)
+}
+
+// Reset removes the value of the setting with key for the guild, restoring its default.
+func Reset(ctx context.Context, storage *database.Storage, guildID string, key string) error {
+	if _, err := Find(key); err != nil {
+		return err
+	}
+
+	if err := checkRelated(ctx, storage, guildID, func(values map[string]string) { delete(values, key) }); err != nil {
+		return fmt.Errorf("can't reset %s: %w", key, err)
+	}
+
+	return storage.DeleteGuildSetting(ctx, guildID, key)
+}
+
+// checkRelated validates the settings that depend on each other, with change applied
+// to the values saved for the guild.
+func checkRelated(ctx context.Context, storage *database.Storage, guildID string, change func(map[string]string)) error {
+	values, err := storage.GuildSettings(ctx, guildID)
+	if err != nil {
+		return err
+	}
+	change(values)
+
+	minRating, err := MovieRatingMin.lookup(values)
+	if err != nil {
+		return nil
+	}
+	maxRating, err := MovieRatingMax.lookup(values)
+	if err != nil {
+		return nil
+	}
+	if minRating > maxRating {
+		return fmt.Errorf("%s (%g) must not be above %s (%g)", MovieRatingMin.key, minRating, MovieRatingMax.key, maxRating)
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package settings
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/LeBulldoge/gungus/internal/database"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		def     Definition
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{Volume, "150", "150", false},
+		{Volume, "0", "", true},
+		{Volume, "loud", "", true},
+		{IdleTimeout, "90s", "1m30s", false},
+		{IdleTimeout, "1s", "", true},
+		{QuoteEphemeral, "1", "true", false},
+		{MovieRatingMax, "5.50", "5.5", false},
+		{OpsChannel, "<#1234567890>", "1234567890", false},
+		{OpsChannel, "#ops", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.def.Normalize(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: wrong error received for %q. got %v", tt.def.Key(), tt.value, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: wrong value received for %q. got %q, expected, %q", tt.def.Key(), tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestGetAndSet(t *testing.T) {
+	ctx := context.Background()
+	storage := database.New(t.TempDir())
+	if err := storage.Open(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer storage.Close()
+
+	if got := IdleTimeout.Get(ctx, storage, "guild"); got != time.Minute {
+		t.Fatalf("wrong default received. got %s, expected, %s", got, time.Minute)
+	}
+
+	// warm the cache before changing the value
+	_ = Volume.Get(ctx, storage, "guild")
+	if _, err := Set(ctx, storage, "guild", Volume.Key(), "50"); err != nil {
+		t.Fatal(err)
+	}
+	if got := Volume.Get(ctx, storage, "guild"); got != 50 {
+		t.Fatalf("wrong value received. got %d, expected, %d", got, 50)
+	}
+	if got := Volume.Get(ctx, storage, "other"); got != 100 {
+		t.Fatalf("setting leaked to another guild. got %d, expected, %d", got, 100)
+	}
+
+	if err := Reset(ctx, storage, "guild", Volume.Key()); err != nil {
+		t.Fatal(err)
+	}
+	if got := Volume.Get(ctx, storage, "guild"); got != 100 {
+		t.Fatalf("wrong value received after reset. got %d, expected, %d", got, 100)
+	}
+
+	if _, err := Set(ctx, storage, "guild", "nope", "1"); err == nil {
+		t.Fatal("no error received for unknown setting")
+	}
+}
+
+func TestRatingRange(t *testing.T) {
+	ctx := context.Background()
+	storage := database.New(t.TempDir())
+	if err := storage.Open(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer storage.Close()
+
+	if _, err := Set(ctx, storage, "guild", MovieRatingMin.Key(), "20"); err == nil {
+		t.Fatal("no error received for a minimum above the maximum")
+	}
+	if _, err := Set(ctx, storage, "guild", MovieRatingMax.Key(), "-50"); err == nil {
+		t.Fatal("no error received for a maximum below the minimum")
+	}
+
+	if _, err := Set(ctx, storage, "guild", MovieRatingMin.Key(), "-60"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Set(ctx, storage, "guild", MovieRatingMax.Key(), "-50"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Reset(ctx, storage, "guild", MovieRatingMin.Key()); err == nil {
+		t.Fatal("no error received for a reset minimum above the maximum")
+	}
+}
@@ -8,9 +8,31 @@ import (
 	"os/exec"
 	"strings"
 
+	"github.com/LeBulldoge/gungus/internal/metrics"
 	"github.com/LeBulldoge/gungus/internal/os"
 )
 
+var executablePath = "yt-dlp"
+
+// SetExecutablePath sets the yt-dlp executable used for all requests.
+func SetExecutablePath(path string) {
+	executablePath = path
+}
+
+func ExecutablePath() string {
+	return executablePath
+}
+
+// Version returns the version reported by the yt-dlp executable.
+func Version(ctx context.Context) (string, error) {
+	out, err := exec.CommandContext(ctx, executablePath, "--version").Output()
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
 type Video struct {
 	URL       string
 	Title     string
@@ -30,7 +52,7 @@ type SearchResult struct {
 
 func SearchYoutube(ctx context.Context, query string, output chan<- SearchResult) error {
 	ytdlp := exec.Command(
-		"yt-dlp",
+		executablePath,
 		"ytsearch5:"+query,
 		"--print", "%(url)s;%(title)s",
 		"--flat-playlist",
@@ -81,6 +103,7 @@ func SearchYoutube(ctx context.Context, query string, output chan<- SearchResult
 		close(output)
 
 		if err := ytdlp.Wait(); err != nil {
+			metrics.ProcessFailures.WithLabelValues(metrics.ProcessYtDlp).Inc()
 			slog.Error("SearchYoutube error on wait", "err", err)
 			return
 		}
@@ -91,7 +114,7 @@ func SearchYoutube(ctx context.Context, query string, output chan<- SearchResult
 
 func GetYoutubeData(ctx context.Context, videoURL string, output chan<- SearchResult) error {
 	ytdlp := exec.Command(
-		"yt-dlp",
+		executablePath,
 		videoURL,
 		"--get-title",
 		"--get-id",
@@ -137,6 +160,7 @@ func GetYoutubeData(ctx context.Context, videoURL string, output chan<- SearchRe
 		slog.Info("GetYoutubeData finished", "videoUrl", videoURL)
 
 		if err := ytdlp.Wait(); err != nil {
+			metrics.ProcessFailures.WithLabelValues(metrics.ProcessYtDlp).Inc()
 			res.Error = err
 			select {
 			case <-ctx.Done():